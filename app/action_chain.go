@@ -0,0 +1,161 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/zenon-network/go-zenon/chain/archive"
+	"github.com/zenon-network/go-zenon/node"
+)
+
+var (
+	chainFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "Height of the first momentum to export",
+		Value: 2,
+	}
+	chainToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Height of the last momentum to export, defaults to the frontier momentum",
+	}
+	chainOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Output chain archive",
+	}
+	chainInFlag = cli.StringFlag{
+		Name:  "in",
+		Usage: "Chain archive to import",
+	}
+
+	exportCommand = cli.Command{
+		Action:    exportAction,
+		Name:      "export",
+		Usage:     "Export momentums & account-blocks of the local chain to a compressed archive",
+		ArgsUsage: " ",
+		Category:  "CHAIN COMMANDS",
+		Description: `Reads the chain of the data directory, the node must not be running.
+   The archive can be imported by nodes of the same chain to avoid syncing these momentums from peers.`,
+		Flags: []cli.Flag{chainFromFlag, chainToFlag, chainOutFlag},
+	}
+	importCommand = cli.Command{
+		Action:    importAction,
+		Name:      "import",
+		Usage:     "Import a chain archive created by export",
+		ArgsUsage: " ",
+		Category:  "CHAIN COMMANDS",
+		Description: `Every momentum & account-block is verified, exactly like the ones received from peers.
+   The archive must start at most at the height after the local frontier momentum, momentums which are already part of the chain are skipped.
+   The node must not be running.`,
+		Flags: []cli.Flag{chainInFlag},
+	}
+	verifyChainCommand = cli.Command{
+		Action:    verifyChainAction,
+		Name:      "verify-chain",
+		Usage:     "Replay the local chain from the genesis and report the first momentum which diverges",
+		ArgsUsage: " ",
+		Category:  "CHAIN COMMANDS",
+		Description: `Every stored momentum is replayed into a temporary database through the verifier & VM,
+   the recomputed changes must match the ChangesHash of each momentum.
+   The first divergence is reported with the offending account-block & embedded method.
+   The node must not be running.`,
+	}
+)
+
+func exportAction(ctx *cli.Context) error {
+	if err := requireFlags(ctx, chainOutFlag.Name); err != nil {
+		return err
+	}
+	nodeConfig, err := MakeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	chain, err := node.OpenOfflineChain(nodeConfig)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+
+	file, err := os.Create(ctx.String(chainOutFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	count, err := archive.Export(chain, file, ctx.Uint64(chainFromFlag.Name), ctx.Uint64(chainToFlag.Name))
+	if err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %v momentums to %v\n", count, ctx.String(chainOutFlag.Name))
+	return nil
+}
+
+func importAction(ctx *cli.Context) error {
+	if err := requireFlags(ctx, chainInFlag.Name); err != nil {
+		return err
+	}
+	file, err := os.Open(ctx.String(chainInFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	nodeConfig, err := MakeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	chain, err := node.OpenOfflineChain(nodeConfig)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+
+	count, err := archive.Import(chain, chain.Supervisor(), file)
+	if err != nil {
+		if count != 0 {
+			fmt.Printf("Imported %v momentums before the error\n", count)
+		}
+		return err
+	}
+	frontier, err := chain.GetFrontierMomentumStore().GetFrontierMomentum()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %v momentums. Height: %v, Hash: %v\n", count, frontier.Height, frontier.Hash)
+	return nil
+}
+
+func verifyChainAction(ctx *cli.Context) error {
+	nodeConfig, err := MakeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	chain, err := node.OpenOfflineChain(nodeConfig)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+
+	dir, err := ioutil.TempDir("", "znnd-verify-chain")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	scratch, err := chain.NewScratchChain(dir)
+	if err != nil {
+		return err
+	}
+	defer scratch.Close()
+
+	count, err := archive.Verify(chain, scratch, scratch.Supervisor())
+	if err != nil {
+		fmt.Printf("Verified %v momentums before the divergence\n", count)
+		return err
+	}
+	fmt.Printf("Verified %v momentums\n", count)
+	return nil
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/zenon-network/go-zenon/chain/genesis"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/node"
+	"github.com/zenon-network/go-zenon/wallet"
+)
+
+var (
+	genesisSpecFlag = cli.StringFlag{
+		Name:  "spec",
+		Usage: "JSON file with a genesis spec, the other flags are added to it",
+	}
+	genesisChainIdFlag = cli.Uint64Flag{
+		Name:  "chain-id",
+		Usage: "Chain identifier of the new network",
+	}
+	genesisSporkFlag = cli.StringFlag{
+		Name:  "spork",
+		Usage: "Address allowed to create sporks",
+	}
+	genesisTimestampFlag = cli.Int64Flag{
+		Name:  "timestamp",
+		Usage: "Unix timestamp of the genesis momentum, defaults to the current time",
+	}
+	genesisPillarsFlag = cli.IntFlag{
+		Name:  "pillars",
+		Usage: "Number of pillars to add, their producer addresses are derived from a new mnemonic which is printed",
+	}
+	genesisAllocFlag = cli.StringSliceFlag{
+		Name:  "alloc",
+		Usage: "Balances of an address as address:znn:qsr[:fusedQsr], in base units, repeat for each address",
+	}
+	genesisOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Output genesis file",
+	}
+	genesisInFlag = cli.StringFlag{
+		Name:  "in",
+		Usage: "Genesis file to check",
+	}
+
+	genesisCommand = cli.Command{
+		Name:     "genesis",
+		Usage:    "Generate, export & check genesis files of private networks",
+		Category: "CHAIN COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Action:    genesisNewAction,
+				Name:      "new",
+				Usage:     "Generate a valid genesis file from a spec & flags",
+				ArgsUsage: " ",
+				Description: `Every pillar stakes the pillar amount, which is added to the ZNN supply, and is delegated to by its owner.
+   The total supplies are the sums of all balances. Example spec:
+   {
+       "ChainIdentifier": 3,
+       "SporkAddress": "z1...",
+       "Pillars": [{"Name": "pillar-1", "ProducerAddress": "z1...", "OwnerAddress": "z1..."}],
+       "Allocations": [{"Address": "z1...", "Znn": 100000000000, "Qsr": 1000000000000, "FusedQsr": 1000000000}]
+   }`,
+				Flags: []cli.Flag{genesisSpecFlag, genesisChainIdFlag, genesisSporkFlag, genesisTimestampFlag,
+					genesisPillarsFlag, genesisAllocFlag, genesisOutFlag},
+			},
+			{
+				Action:    genesisExportAction,
+				Name:      "export",
+				Usage:     "Export the state of the local chain as the genesis of a new chain",
+				ArgsUsage: " ",
+				Description: `Reads the frontier momentum of the data directory, the node must not be running.
+   Balances, pillars, delegations, tokens, fusions, swap entries & sporks are exported.
+   The storage of the other embedded contracts, like stakes or sentinels, is not part of a genesis.`,
+				Flags: []cli.Flag{genesisChainIdFlag, genesisSporkFlag, genesisOutFlag},
+			},
+			{
+				Action:    genesisCheckAction,
+				Name:      "check",
+				Usage:     "Print every problem of a genesis file",
+				ArgsUsage: " ",
+				Flags:     []cli.Flag{genesisInFlag},
+			},
+		},
+	}
+)
+
+// parseAllocation parses address:znn:qsr[:fusedQsr]
+func parseAllocation(str string) (*genesis.AllocationSpec, error) {
+	parts := strings.Split(str, ":")
+	if len(parts) != 3 && len(parts) != 4 {
+		return nil, fmt.Errorf("invalid allocation %q, expected address:znn:qsr[:fusedQsr]", str)
+	}
+	address, err := types.ParseAddress(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	amounts := make([]*big.Int, 3)
+	for i, part := range parts[1:] {
+		amount, ok := new(big.Int).SetString(part, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q in allocation %q", part, str)
+		}
+		amounts[i] = amount
+	}
+	return &genesis.AllocationSpec{Address: address, Znn: amounts[0], Qsr: amounts[1], FusedQsr: amounts[2]}, nil
+}
+
+func genesisNewAction(ctx *cli.Context) error {
+	if err := requireFlags(ctx, genesisOutFlag.Name); err != nil {
+		return err
+	}
+
+	spec := &genesis.GenesisSpec{}
+	if path := ctx.String(genesisSpecFlag.Name); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, spec); err != nil {
+			return fmt.Errorf("malformed genesis spec. Reason:%w", err)
+		}
+	}
+	if ctx.IsSet(genesisChainIdFlag.Name) {
+		spec.ChainIdentifier = ctx.Uint64(genesisChainIdFlag.Name)
+	}
+	if spork := ctx.String(genesisSporkFlag.Name); spork != "" {
+		address, err := types.ParseAddress(spork)
+		if err != nil {
+			return err
+		}
+		spec.SporkAddress = address
+	}
+	if ctx.IsSet(genesisTimestampFlag.Name) {
+		spec.GenesisTimestampSec = ctx.Int64(genesisTimestampFlag.Name)
+	}
+	if spec.GenesisTimestampSec == 0 {
+		spec.GenesisTimestampSec = time.Now().Unix()
+	}
+	for _, str := range ctx.StringSlice(genesisAllocFlag.Name) {
+		allocation, err := parseAllocation(str)
+		if err != nil {
+			return err
+		}
+		spec.Allocations = append(spec.Allocations, allocation)
+	}
+
+	var pillarKeys *wallet.KeyStore
+	if count := ctx.Int(genesisPillarsFlag.Name); count > 0 {
+		var err error
+		if pillarKeys, err = wallet.NewKeyStore(); err != nil {
+			return err
+		}
+		defer pillarKeys.Zero()
+		first := len(spec.Pillars)
+		for i := 0; i < count; i++ {
+			_, keyPair, err := pillarKeys.DeriveForIndexPath(uint32(i))
+			if err != nil {
+				return err
+			}
+			spec.Pillars = append(spec.Pillars, &genesis.PillarSpec{
+				Name:            fmt.Sprintf("pillar-%v", first+i+1),
+				ProducerAddress: keyPair.Address,
+			})
+		}
+	}
+
+	config, err := genesis.NewGenesisConfigFromSpec(spec)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(config, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(ctx.String(genesisOutFlag.Name), data, 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated the genesis of chain %v with %v pillars, genesis momentum %v\n",
+		config.ChainIdentifier, len(config.PillarConfig.Pillars), genesis.NewGenesis(config).GetGenesisMomentum().Hash)
+	if pillarKeys != nil {
+		fmt.Printf("The producer addresses of the new pillars are derived, in order, from this mnemonic. Store it safely!\n%v\n", pillarKeys.Mnemonic)
+	}
+	return nil
+}
+
+func genesisExportAction(ctx *cli.Context) error {
+	if err := requireFlags(ctx, genesisOutFlag.Name); err != nil {
+		return err
+	}
+	var sporkAddress *types.Address
+	if spork := ctx.String(genesisSporkFlag.Name); spork != "" {
+		address, err := types.ParseAddress(spork)
+		if err != nil {
+			return err
+		}
+		sporkAddress = &address
+	}
+
+	nodeConfig, err := MakeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	chain, err := node.OpenOfflineChain(nodeConfig)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+
+	config, err := genesis.ExportGenesisConfig(chain.GetFrontierMomentumStore(), ctx.Uint64(genesisChainIdFlag.Name), sporkAddress)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(config, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(ctx.String(genesisOutFlag.Name), data, 0600); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %v accounts, %v pillars & %v tokens as the genesis of chain %v\n",
+		len(config.GenesisBlocks.Blocks), len(config.PillarConfig.Pillars), len(config.TokenConfig.Tokens), config.ChainIdentifier)
+	return nil
+}
+
+func genesisCheckAction(ctx *cli.Context) error {
+	if err := requireFlags(ctx, genesisInFlag.Name); err != nil {
+		return err
+	}
+	file, err := os.Open(ctx.String(genesisInFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	config, err := genesis.DecodeGenesisConfig(file)
+	if err != nil {
+		return fmt.Errorf("malformed genesis json. Reason:%w", err)
+	}
+
+	if problems := genesis.ValidateGenesis(config); len(problems) != 0 {
+		for _, problem := range problems {
+			fmt.Printf("- %v\n", problem)
+		}
+		return fmt.Errorf("the genesis has %v problems", len(problems))
+	}
+	fmt.Printf("Valid genesis of chain %v with %v pillars, genesis momentum %v\n",
+		config.ChainIdentifier, len(config.PillarConfig.Pillars), genesis.NewGenesis(config).GetGenesisMomentum().Hash)
+	return nil
+}
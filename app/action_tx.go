@@ -0,0 +1,286 @@
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/offline"
+	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/rpc/server"
+	"github.com/zenon-network/go-zenon/wallet"
+)
+
+var (
+	txUrlFlag = cli.StringFlag{
+		Name:  "url",
+		Usage: "RPC endpoint of an online node, http, ws or IPC path",
+		Value: fmt.Sprintf("http://127.0.0.1:%d", p2p.DefaultHTTPPort),
+	}
+	txAddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "Address which will sign the block",
+	}
+	txChainInfoFlag = cli.StringFlag{
+		Name:  "chain-info",
+		Usage: "Chain-info file exported by 'tx chain-info'",
+	}
+	txToFlag = cli.StringFlag{
+		Name:  "to",
+		Usage: "Destination address of a send-block",
+	}
+	txAmountFlag = cli.StringFlag{
+		Name:  "amount",
+		Usage: "Amount to send, in base units",
+		Value: "0",
+	}
+	txTokenFlag = cli.StringFlag{
+		Name:  "token",
+		Usage: "Token standard to send",
+		Value: types.ZnnTokenStandard.String(),
+	}
+	txDataFlag = cli.StringFlag{
+		Name:  "data",
+		Usage: "Hex encoded data of a send-block",
+	}
+	txContractFlag = cli.StringFlag{
+		Name:  "contract",
+		Usage: fmt.Sprintf("Embedded contract to call, one of %v", strings.Join(offline.EmbeddedContractNames(), ", ")),
+	}
+	txMethodFlag = cli.StringFlag{
+		Name:  "method",
+		Usage: "Method of the embedded contract, as named in its ABI",
+	}
+	txArgFlag = cli.StringSliceFlag{
+		Name:  "arg",
+		Usage: "Argument of the embedded method, repeat for each argument in order",
+	}
+	txReceiveFlag = cli.StringFlag{
+		Name:  "receive",
+		Usage: "Hash of the send-block to receive, builds a receive-block instead of a send-block",
+	}
+	txFusedPlasmaFlag = cli.Uint64Flag{
+		Name:  "fused-plasma",
+		Usage: "Fused plasma of the block, defaults to the base plasma of the block",
+	}
+	txDifficultyFlag = cli.Uint64Flag{
+		Name:  "difficulty",
+		Usage: "PoW difficulty which covers the plasma which isn't fused",
+	}
+	txKeyFileFlag = cli.StringFlag{
+		Name:  "keyfile",
+		Usage: "KeyFile which holds the address of the block",
+	}
+	txPasswordFileFlag = cli.StringFlag{
+		Name:  "password-file",
+		Usage: "File which holds the KeyFile password, prompted for if missing",
+	}
+	txInFlag = cli.StringFlag{
+		Name:  "in",
+		Usage: "Input file",
+	}
+	txOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Output file",
+	}
+
+	txCommand = cli.Command{
+		Name:     "tx",
+		Usage:    "Build & sign account-blocks on air-gapped machines",
+		Category: "TRANSACTION COMMANDS",
+		Description: `The chain-dependent fields are exported on an online machine with 'tx chain-info', the block is built
+   with 'tx build' & signed with 'tx sign' on the air-gapped machine. The signed block is accepted by
+   ledger.publishRawTransaction.`,
+		Subcommands: []cli.Command{
+			{
+				Action:    txChainInfoAction,
+				Name:      "chain-info",
+				Usage:     "Export the chain-info of an address from an online node",
+				ArgsUsage: " ",
+				Flags:     []cli.Flag{txUrlFlag, txAddressFlag, txOutFlag},
+			},
+			{
+				Action:    txBuildAction,
+				Name:      "build",
+				Usage:     "Build an unsigned account-block",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{txChainInfoFlag, txToFlag, txAmountFlag, txTokenFlag, txDataFlag,
+					txContractFlag, txMethodFlag, txArgFlag, txReceiveFlag, txFusedPlasmaFlag, txDifficultyFlag, txOutFlag},
+			},
+			{
+				Action:    txSignAction,
+				Name:      "sign",
+				Usage:     "Sign an account-block built by 'tx build'",
+				ArgsUsage: " ",
+				Flags:     []cli.Flag{txKeyFileFlag, txPasswordFileFlag, txInFlag, txOutFlag},
+			},
+		},
+	}
+)
+
+func requireFlags(ctx *cli.Context, names ...string) error {
+	for _, name := range names {
+		if ctx.String(name) == "" {
+			return fmt.Errorf("missing required flag --%v", name)
+		}
+	}
+	return nil
+}
+
+func txChainInfoAction(ctx *cli.Context) error {
+	if err := requireFlags(ctx, txAddressFlag.Name, txOutFlag.Name); err != nil {
+		return err
+	}
+	address, err := types.ParseAddress(ctx.String(txAddressFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	client, err := server.Dial(ctx.String(txUrlFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	var frontier *nom.AccountBlock
+	if err := client.Call(&frontier, "ledger.getFrontierAccountBlock", address); err != nil {
+		return err
+	}
+	var momentum *nom.Momentum
+	if err := client.Call(&momentum, "ledger.getFrontierMomentum"); err != nil {
+		return err
+	}
+
+	info, err := offline.NewChainInfo(address, frontier, momentum)
+	if err != nil {
+		return err
+	}
+	if err := info.Write(ctx.String(txOutFlag.Name)); err != nil {
+		return err
+	}
+	fmt.Printf("Exported chain-info of %v at height %v, acknowledging momentum %v\n", address, info.Frontier.Height, info.MomentumAcknowledged.Height)
+	return nil
+}
+
+func txBuildAction(ctx *cli.Context) error {
+	if err := requireFlags(ctx, txChainInfoFlag.Name, txOutFlag.Name); err != nil {
+		return err
+	}
+	info, err := offline.ReadChainInfo(ctx.String(txChainInfoFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	template := &nom.AccountBlock{
+		FusedPlasma: ctx.Uint64(txFusedPlasmaFlag.Name),
+		Difficulty:  ctx.Uint64(txDifficultyFlag.Name),
+	}
+	if receive := ctx.String(txReceiveFlag.Name); receive != "" {
+		template.BlockType = nom.BlockTypeUserReceive
+		if template.FromBlockHash, err = types.HexToHash(receive); err != nil {
+			return err
+		}
+	} else if err := setSendFields(ctx, template); err != nil {
+		return err
+	}
+
+	block, err := offline.Build(context.Background(), info, template)
+	if err != nil {
+		return err
+	}
+	if err := offline.WriteBlock(ctx.String(txOutFlag.Name), block); err != nil {
+		return err
+	}
+	fmt.Printf("Built block %v of %v with %v fused plasma & difficulty %v\n", block.Height, block.Address, block.FusedPlasma, block.Difficulty)
+	return nil
+}
+
+func setSendFields(ctx *cli.Context, template *nom.AccountBlock) error {
+	var err error
+	template.BlockType = nom.BlockTypeUserSend
+	if template.TokenStandard, err = types.ParseZTS(ctx.String(txTokenFlag.Name)); err != nil {
+		return err
+	}
+	amount, ok := new(big.Int).SetString(ctx.String(txAmountFlag.Name), 10)
+	if !ok || amount.Sign() == -1 {
+		return fmt.Errorf("invalid amount %q", ctx.String(txAmountFlag.Name))
+	}
+	template.Amount = amount
+
+	if contract := ctx.String(txContractFlag.Name); contract != "" {
+		if ctx.String(txToFlag.Name) != "" || ctx.String(txDataFlag.Name) != "" {
+			return fmt.Errorf("--%v can't be used together with --%v or --%v", txContractFlag.Name, txToFlag.Name, txDataFlag.Name)
+		}
+		if err := requireFlags(ctx, txMethodFlag.Name); err != nil {
+			return err
+		}
+		template.ToAddress, template.Data, err = offline.PackEmbeddedCall(contract, ctx.String(txMethodFlag.Name), ctx.StringSlice(txArgFlag.Name))
+		return err
+	}
+
+	if err := requireFlags(ctx, txToFlag.Name); err != nil {
+		return err
+	}
+	if template.ToAddress, err = types.ParseAddress(ctx.String(txToFlag.Name)); err != nil {
+		return err
+	}
+	if data := ctx.String(txDataFlag.Name); data != "" {
+		if template.Data, err = hex.DecodeString(strings.TrimPrefix(data, "0x")); err != nil {
+			return fmt.Errorf("invalid data: %v", err)
+		}
+	}
+	return nil
+}
+
+func txSignAction(ctx *cli.Context) error {
+	if err := requireFlags(ctx, txKeyFileFlag.Name, txInFlag.Name, txOutFlag.Name); err != nil {
+		return err
+	}
+	keyFile, err := wallet.ReadKeyFile(ctx.String(txKeyFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	block, err := offline.ReadBlock(ctx.String(txInFlag.Name))
+	if err != nil {
+		return err
+	}
+	password, err := readPassword(ctx.String(txPasswordFileFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	if err := offline.Sign(block, keyFile, password); err != nil {
+		return err
+	}
+	if err := offline.WriteBlock(ctx.String(txOutFlag.Name), block); err != nil {
+		return err
+	}
+	fmt.Printf("Signed block %v of %v, hash %v\n", block.Height, block.Address, block.Hash)
+	return nil
+}
+
+// readPassword reads the first line of path, or prompts for the password if path is empty
+func readPassword(path string) (string, error) {
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r"), nil
+	}
+	fmt.Print("KeyFile password: ")
+	password, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
@@ -45,6 +45,11 @@ func init() {
 	app.Commands = []cli.Command{
 		versionCommand,
 		licenseCommand,
+		txCommand,
+		genesisCommand,
+		exportCommand,
+		importCommand,
+		verifyChainCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
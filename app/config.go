@@ -104,6 +104,39 @@ func applyFlagsToConfig(ctx *cli.Context, cfg *node.Config) {
 		cfg.RPC.WSPort = ctx.GlobalInt(WSPortFlag.Name)
 	}
 
+	// IPC Config
+	if ipcPath := ctx.GlobalString(IPCPathFlag.Name); ctx.GlobalIsSet(IPCPathFlag.Name) && len(ipcPath) > 0 {
+		cfg.RPC.IPCPath = ipcPath
+	}
+
+	if ctx.GlobalBool(IPCDisableFlag.Name) {
+		cfg.RPC.IPCPath = ""
+	}
+
+	// PoW Config
+	if ctx.GlobalIsSet(PoWEnabledFlag.Name) {
+		cfg.RPC.EnablePoW = ctx.GlobalBool(PoWEnabledFlag.Name)
+	}
+
+	// Metrics Config
+	if ctx.GlobalIsSet(MetricsEnabledFlag.Name) {
+		cfg.Metrics.Enabled = ctx.GlobalBool(MetricsEnabledFlag.Name)
+	}
+	if metricsHost := ctx.GlobalString(MetricsListenAddrFlag.Name); ctx.GlobalIsSet(MetricsListenAddrFlag.Name) && len(metricsHost) > 0 {
+		cfg.Metrics.HTTPHost = metricsHost
+	}
+	if ctx.GlobalIsSet(MetricsPortFlag.Name) {
+		cfg.Metrics.HTTPPort = ctx.GlobalInt(MetricsPortFlag.Name)
+	}
+
+	// Dev Config
+	if ctx.GlobalIsSet(DevFlag.Name) {
+		cfg.Dev.Enabled = ctx.GlobalBool(DevFlag.Name)
+	}
+	if ctx.GlobalIsSet(DevPeriodFlag.Name) {
+		cfg.Dev.Period = ctx.GlobalInt64(DevPeriodFlag.Name)
+	}
+
 	// Log Level Config
 	if logLevel := ctx.GlobalString(LogLvlFlag.Name); ctx.GlobalIsSet(LogLvlFlag.Name) && len(logLevel) > 0 {
 		cfg.LogLevel = logLevel
@@ -89,6 +89,47 @@ var (
 		Usage: "WS-RPC server listening port",
 		Value: p2p.DefaultWSPort,
 	}
+	IPCDisableFlag = cli.BoolFlag{
+		Name:  "ipc-disable",
+		Usage: "Disable the IPC-RPC server",
+	}
+	IPCPathFlag = cli.StringFlag{
+		Name:  "ipc-path",
+		Usage: "Filename for the IPC socket/pipe, relative paths are placed in DataPath. Serves all modules, including the private ones",
+		Value: "DataPath/" + node.DefaultIPCPath,
+	}
+	PoWEnabledFlag = cli.BoolFlag{
+		Name:  "rpc-pow",
+		Usage: "Enable the ledger.generatePoW RPC method. The node will compute PoW nonces for clients",
+	}
+
+	// metrics
+
+	MetricsEnabledFlag = cli.BoolFlag{
+		Name:  "metrics", // also checked by go-ethereum/metrics on init, which enables the collection
+		Usage: "Enable metrics collection and the Prometheus /metrics listener",
+	}
+	MetricsListenAddrFlag = cli.StringFlag{
+		Name:  "metrics-addr",
+		Usage: "Metrics server listening interface",
+		Value: node.DefaultMetricsHost,
+	}
+	MetricsPortFlag = cli.IntFlag{
+		Name:  "metrics-port",
+		Usage: "Metrics server listening port",
+		Value: node.DefaultMetricsPort,
+	}
+
+	// dev
+
+	DevFlag = cli.BoolFlag{
+		Name:  "dev",
+		Usage: "Run a single-node dev chain with a local pillar and prefunded accounts, stored in DataPath/dev unless --data is set",
+	}
+	DevPeriodFlag = cli.Int64Flag{
+		Name:  "dev-period",
+		Usage: "Seconds after which the dev chain produces an empty momentum (0 = momentums are only produced for new transactions)",
+	}
 
 	// log
 
@@ -122,6 +163,22 @@ var (
 		WSListenAddrFlag,
 		WSPortFlag,
 
+		// ipc
+		IPCDisableFlag,
+		IPCPathFlag,
+
+		// pow
+		PoWEnabledFlag,
+
+		// metrics
+		MetricsEnabledFlag,
+		MetricsListenAddrFlag,
+		MetricsPortFlag,
+
+		// dev
+		DevFlag,
+		DevPeriodFlag,
+
 		//Log
 		LogLvlFlag,
 	}
@@ -60,7 +60,10 @@ func (nodeManager *Manager) Start() error {
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
 		defer signal.Stop(c)
-		<-c
+		select {
+		case <-c:
+		case <-nodeManager.node.ShutdownRequested():
+		}
 		fmt.Println("Shutting down znnd")
 
 		go func() {
@@ -0,0 +1,165 @@
+package archive
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/vm"
+)
+
+const (
+	exportBatchSize = 100
+	logEvery        = 10000
+)
+
+var (
+	log = common.ChainLogger.New("submodule", "archive")
+)
+
+// Export writes the momentums between heights from & to, inclusive, with their account-blocks.
+// The genesis momentum is skipped since every node builds it from its genesis config.
+// A zero to exports up to the frontier momentum. Returns the number of exported momentums.
+func Export(c chain.Chain, w io.Writer, from, to uint64) (uint64, error) {
+	store := c.GetFrontierMomentumStore()
+	frontier, err := store.GetFrontierMomentum()
+	if err != nil {
+		return 0, err
+	}
+	if from < 2 {
+		from = 2
+	}
+	if to == 0 || to > frontier.Height {
+		to = frontier.Height
+	}
+	if from > to {
+		return 0, errors.Errorf("nothing to export between heights %v and %v, the frontier momentum is at height %v", from, to, frontier.Height)
+	}
+
+	writer, err := NewWriter(w, Header{
+		ChainIdentifier: c.ChainIdentifier(),
+		GenesisHash:     c.GetGenesisMomentum().Hash,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	for height := from; height <= to; height += exportBatchSize {
+		size := uint64(exportBatchSize)
+		if height+size > to+1 {
+			size = to + 1 - height
+		}
+		momentums, err := store.GetMomentumsByHeight(height, true, size)
+		if err != nil {
+			return count, err
+		}
+		for _, momentum := range momentums {
+			detailed, err := store.PrefetchMomentum(momentum)
+			if err != nil {
+				return count, err
+			}
+			if err := writer.Write(detailed); err != nil {
+				return count, err
+			}
+			count += 1
+			if count%logEvery == 0 {
+				log.Info("exported momentums", "count", count, "identifier", momentum.Identifier())
+			}
+		}
+	}
+	return count, writer.Close()
+}
+
+// Import inserts the momentums of the archive after the frontier momentum. Every account-block & momentum
+// is verified and applied by supervisor, exactly like the ones received from peers.
+// Momentums which are already part of the chain are skipped. Returns the number of inserted momentums.
+func Import(c chain.Chain, supervisor *vm.Supervisor, r io.Reader) (uint64, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	if reader.ChainIdentifier != c.ChainIdentifier() || reader.GenesisHash != c.GetGenesisMomentum().Hash {
+		return 0, ErrChainMismatch
+	}
+
+	var count uint64
+	for {
+		detailed, err := reader.Read()
+		if err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return count, err
+		}
+		inserted, err := insertMomentum(c, supervisor, detailed)
+		if _, ok := err.(*Divergence); ok {
+			return count, err
+		} else if err != nil {
+			return count, errors.Wrapf(err, "failed to import momentum %v", detailed.Momentum.Identifier())
+		}
+		if inserted {
+			count += 1
+			if count%logEvery == 0 {
+				log.Info("imported momentums", "count", count, "identifier", detailed.Momentum.Identifier())
+			}
+		}
+	}
+}
+
+func insertMomentum(c chain.Chain, supervisor *vm.Supervisor, detailed *nom.DetailedMomentum) (bool, error) {
+	momentum := detailed.Momentum
+	insert := c.AcquireInsert(fmt.Sprintf("Import momentum %v", momentum.Identifier()))
+	defer insert.Unlock()
+
+	store := c.GetFrontierMomentumStore()
+	if our, err := store.GetMomentumByHeight(momentum.Height); err != nil {
+		return false, err
+	} else if our != nil {
+		if our.Hash != momentum.Hash {
+			return false, errors.Errorf("the chain has momentum %v at the same height", our.Identifier())
+		}
+		return false, nil
+	}
+	frontier, err := store.GetFrontierMomentum()
+	if err != nil {
+		return false, err
+	}
+	if momentum.Previous() != frontier.Identifier() {
+		return false, errors.Wrapf(ErrMomentumNotLinked, "frontier momentum is %v", frontier.Identifier())
+	}
+
+	for _, block := range detailed.AccountBlocks {
+		if block.BlockType == nom.BlockTypeContractSend {
+			continue
+		}
+		if patch := c.GetPatch(block.Address, block.Identifier()); patch != nil {
+			// already applied
+			continue
+		}
+		transaction, err := supervisor.ApplyBlock(block)
+		if err != nil {
+			return false, &Divergence{
+				Momentum: momentum.Identifier(),
+				Block:    block,
+				Method:   embeddedMethod(store, block),
+				Reason:   err,
+			}
+		}
+		if err := c.ForceAddAccountBlockTransaction(insert, transaction); err != nil {
+			return false, err
+		}
+	}
+
+	transaction, err := supervisor.ApplyMomentum(detailed)
+	if err != nil {
+		return false, err
+	}
+	if err := c.AddMomentumTransaction(insert, transaction); err != nil {
+		return false, err
+	}
+	return true, nil
+}
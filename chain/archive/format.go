@@ -0,0 +1,193 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+// An archive is a gzip stream made of a header followed by DetailedMomentums in ascending order.
+// Each momentum & account-block is protobuf-encoded and prefixed by its uvarint length.
+
+const (
+	formatVersion = 1
+	// maxRecordSize is the max size of a single encoded momentum or account-block
+	maxRecordSize = 16 * 1024 * 1024
+)
+
+var (
+	magic = []byte("znnchain")
+
+	ErrInvalidArchive    = errors.New("invalid chain archive")
+	ErrChainMismatch     = errors.New("the archive was exported from a different chain")
+	ErrRecordTooLarge    = errors.New("chain archive record exceeds the max size")
+	ErrUnknownVersion    = errors.New("unknown chain archive version")
+	ErrMomentumNotLinked = errors.New("the momentum does not link to the frontier momentum")
+)
+
+// Header identifies the chain of an archive
+type Header struct {
+	ChainIdentifier uint64
+	GenesisHash     types.Hash
+}
+
+type Writer struct {
+	gzip   *gzip.Writer
+	buffer *bufio.Writer
+}
+
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	zw := gzip.NewWriter(w)
+	writer := &Writer{
+		gzip:   zw,
+		buffer: bufio.NewWriter(zw),
+	}
+	if _, err := writer.buffer.Write(common.JoinBytes(magic, []byte{formatVersion}, common.Uint64ToBytes(header.ChainIdentifier), header.GenesisHash.Bytes())); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *Writer) writeRecord(data []byte) error {
+	if len(data) > maxRecordSize {
+		return ErrRecordTooLarge
+	}
+	size := make([]byte, binary.MaxVarintLen64)
+	if _, err := w.buffer.Write(size[:binary.PutUvarint(size, uint64(len(data)))]); err != nil {
+		return err
+	}
+	_, err := w.buffer.Write(data)
+	return err
+}
+
+func (w *Writer) Write(detailed *nom.DetailedMomentum) error {
+	data, err := detailed.Momentum.Serialize()
+	if err != nil {
+		return err
+	}
+	if err := w.writeRecord(data); err != nil {
+		return err
+	}
+	size := make([]byte, binary.MaxVarintLen64)
+	if _, err := w.buffer.Write(size[:binary.PutUvarint(size, uint64(len(detailed.AccountBlocks)))]); err != nil {
+		return err
+	}
+	for _, block := range detailed.AccountBlocks {
+		data, err := block.Serialize()
+		if err != nil {
+			return err
+		}
+		if err := w.writeRecord(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close flushes the archive, it doesn't close the underlying writer
+func (w *Writer) Close() error {
+	if err := w.buffer.Flush(); err != nil {
+		return err
+	}
+	return w.gzip.Close()
+}
+
+type Reader struct {
+	Header
+	gzip   *gzip.Reader
+	buffer *bufio.Reader
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidArchive, err.Error())
+	}
+	reader := &Reader{
+		gzip:   zr,
+		buffer: bufio.NewReader(zr),
+	}
+
+	header := make([]byte, len(magic)+1+8+types.HashSize)
+	if _, err := io.ReadFull(reader.buffer, header); err != nil {
+		return nil, errors.Wrap(ErrInvalidArchive, err.Error())
+	}
+	if !bytes.Equal(header[:len(magic)], magic) {
+		return nil, ErrInvalidArchive
+	}
+	if header[len(magic)] != formatVersion {
+		return nil, ErrUnknownVersion
+	}
+	header = header[len(magic)+1:]
+	reader.ChainIdentifier = common.BytesToUint64(header[:8])
+	if err := reader.GenesisHash.SetBytes(header[8:]); err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+func (r *Reader) readSize() (uint64, error) {
+	return binary.ReadUvarint(r.buffer)
+}
+func (r *Reader) readRecord() ([]byte, error) {
+	size, err := r.readSize()
+	if err != nil {
+		return nil, err
+	}
+	if size > maxRecordSize {
+		return nil, ErrRecordTooLarge
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.buffer, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Read returns the next momentum of the archive, or io.EOF after the last one
+func (r *Reader) Read() (*nom.DetailedMomentum, error) {
+	data, err := r.readRecord()
+	if err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, errors.Wrap(ErrInvalidArchive, err.Error())
+	}
+	momentum, err := nom.DeserializeMomentum(data)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidArchive, err.Error())
+	}
+
+	count, err := r.readSize()
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidArchive, err.Error())
+	}
+	if count != uint64(len(momentum.Content)) {
+		return nil, errors.Wrapf(ErrInvalidArchive, "momentum %v has %v account-blocks but the archive has %v", momentum.Identifier(), len(momentum.Content), count)
+	}
+	detailed := &nom.DetailedMomentum{
+		Momentum:      momentum,
+		AccountBlocks: make([]*nom.AccountBlock, count),
+	}
+	for i := range detailed.AccountBlocks {
+		data, err := r.readRecord()
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArchive, err.Error())
+		}
+		if detailed.AccountBlocks[i], err = nom.DeserializeAccountBlock(data); err != nil {
+			return nil, errors.Wrap(ErrInvalidArchive, err.Error())
+		}
+	}
+	return detailed, nil
+}
+
+func (r *Reader) Close() error {
+	return r.gzip.Close()
+}
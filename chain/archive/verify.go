@@ -0,0 +1,198 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/embedded"
+)
+
+// Divergence is returned for the first momentum which can't be replayed with the stored changes
+type Divergence struct {
+	Momentum types.HashHeight
+	// Block is the account-block which failed, or the first account-block of the first account
+	// whose state differs after the momentum. It's nil if all accounts have the same state.
+	Block *nom.AccountBlock
+	// Method is the embedded method called by Block, if any
+	Method string
+	Reason error
+}
+
+func (d *Divergence) Error() string {
+	if d.Block == nil {
+		return fmt.Sprintf("momentum %v diverged: %v", d.Momentum, d.Reason)
+	}
+	message := fmt.Sprintf("momentum %v diverged at account-block %v type %v", d.Momentum, d.Block.Header(), d.Block.BlockType)
+	if d.Method != "" {
+		message += fmt.Sprintf(" embedded method %v", d.Method)
+	}
+	return fmt.Sprintf("%v: %v", message, d.Reason)
+}
+func (d *Divergence) Unwrap() error {
+	return d.Reason
+}
+
+// Verify replays every momentum of source, after the genesis, into target which must only contain the same genesis.
+// Changes are recomputed by supervisor, which verifies them against the ChangesHash of each momentum.
+// Returns the number of replayed momentums and a *Divergence for the first one which differs.
+func Verify(source chain.Chain, target chain.Chain, supervisor *vm.Supervisor) (uint64, error) {
+	if source.GetGenesisMomentum().Hash != target.GetGenesisMomentum().Hash {
+		return 0, ErrChainMismatch
+	}
+	sourceStore := source.GetFrontierMomentumStore()
+	frontier, err := sourceStore.GetFrontierMomentum()
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	for height := uint64(2); height <= frontier.Height; height += exportBatchSize {
+		size := uint64(exportBatchSize)
+		if height+size > frontier.Height+1 {
+			size = frontier.Height + 1 - height
+		}
+		momentums, err := sourceStore.GetMomentumsByHeight(height, true, size)
+		if err != nil {
+			return count, err
+		}
+		for _, momentum := range momentums {
+			detailed, err := sourceStore.PrefetchMomentum(momentum)
+			if err != nil {
+				return count, err
+			}
+			if _, err := insertMomentum(target, supervisor, detailed); err != nil {
+				if divergence, ok := err.(*Divergence); ok {
+					return count, divergence
+				}
+				return count, findDivergence(source, target, detailed, err)
+			}
+			count += 1
+			if count%logEvery == 0 {
+				log.Info("verified momentums", "count", count, "identifier", momentum.Identifier())
+			}
+		}
+	}
+	return count, nil
+}
+
+// embeddedMethod returns the embedded method called by block, or an empty string
+func embeddedMethod(momentumStore store.Momentum, block *nom.AccountBlock) string {
+	address, data := block.ToAddress, block.Data
+	if block.BlockType == nom.BlockTypeContractReceive {
+		sendBlock, err := momentumStore.GetAccountBlockByHash(block.FromBlockHash)
+		if err != nil || sendBlock == nil {
+			return ""
+		}
+		address, data = block.Address, sendBlock.Data
+	} else if block.BlockType != nom.BlockTypeUserSend {
+		return ""
+	}
+	name, err := embedded.GetEmbeddedMethodName(address, data)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// findDivergence compares the accounts changed by detailed in source, after the momentum, with the ones replayed in target
+func findDivergence(source chain.Chain, target chain.Chain, detailed *nom.DetailedMomentum, reason error) error {
+	momentumStore := source.GetMomentumStore(detailed.Momentum.Identifier())
+	divergence := &Divergence{
+		Momentum: detailed.Momentum.Identifier(),
+		Reason:   reason,
+	}
+	if momentumStore == nil {
+		return divergence
+	}
+
+	compared := make(map[types.Address]bool)
+	for _, block := range detailed.AccountBlocks {
+		if compared[block.Address] {
+			continue
+		}
+		compared[block.Address] = true
+		equal, err := equalAccounts(momentumStore.GetAccountStore(block.Address), target.GetFrontierAccountStore(block.Address))
+		if err != nil {
+			return errors.Wrapf(err, "failed to compare account %v after %v", block.Address, reason)
+		}
+		if !equal {
+			divergence.Block = block
+			divergence.Method = embeddedMethod(momentumStore, block)
+			return divergence
+		}
+	}
+	return divergence
+}
+
+func equalAccounts(a, b store.Account) (bool, error) {
+	if a == nil || b == nil {
+		return a == b, nil
+	}
+	aBalances, err := a.GetBalanceMap()
+	if err != nil {
+		return false, err
+	}
+	bBalances, err := b.GetBalanceMap()
+	if err != nil {
+		return false, err
+	}
+	nonZero := func(balances map[types.ZenonTokenStandard]*big.Int) int {
+		count := 0
+		for _, amount := range balances {
+			if amount.Sign() != 0 {
+				count += 1
+			}
+		}
+		return count
+	}
+	if nonZero(aBalances) != nonZero(bBalances) {
+		return false, nil
+	}
+	for zts, amount := range aBalances {
+		other, ok := bBalances[zts]
+		if amount.Sign() != 0 && (!ok || amount.Cmp(other) != 0) {
+			return false, nil
+		}
+	}
+
+	aIterator := a.Storage().NewIterator([]byte{})
+	defer aIterator.Release()
+	bIterator := b.Storage().NewIterator([]byte{})
+	defer bIterator.Release()
+	// deleted keys may be returned with empty values
+	next := func(iterator db.StorageIterator) bool {
+		for iterator.Next() {
+			if len(iterator.Value()) != 0 {
+				return true
+			}
+		}
+		return false
+	}
+	for {
+		aNext, bNext := next(aIterator), next(bIterator)
+		if aIterator.Error() != nil {
+			return false, aIterator.Error()
+		}
+		if bIterator.Error() != nil {
+			return false, bIterator.Error()
+		}
+		if aNext != bNext {
+			return false, nil
+		}
+		if !aNext {
+			return true, nil
+		}
+		if !bytes.Equal(aIterator.Key(), bIterator.Key()) || !bytes.Equal(aIterator.Value(), bIterator.Value()) {
+			return false, nil
+		}
+	}
+}
@@ -2,6 +2,7 @@ package genesis
 
 import (
 	"encoding/json"
+	"io"
 	"math/big"
 	"os"
 
@@ -22,6 +23,15 @@ func MakeEmbeddedGenesisConfig() (store.Genesis, error) {
 	return NewGenesis(embeddedGenesis), nil
 }
 
+// DecodeGenesisConfig reads the JSON of a GenesisConfig without checking it
+func DecodeGenesisConfig(r io.Reader) (*GenesisConfig, error) {
+	config := new(GenesisConfig)
+	if err := json.NewDecoder(r).Decode(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func ReadGenesisConfigFromFile(genesisFile string) (store.Genesis, error) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -39,8 +49,8 @@ func ReadGenesisConfigFromFile(genesisFile string) (store.Genesis, error) {
 		}
 		defer file.Close()
 
-		config = new(GenesisConfig)
-		if err := json.NewDecoder(file).Decode(config); err != nil {
+		config, err = DecodeGenesisConfig(file)
+		if err != nil {
 			log.Crit("invalid genesis file", "method", "readGenesis", "reason", err, "genesisFile", genesisFile)
 			if err.Error() == "unexpected EOF" || err.Error() == "EOF" {
 				return nil, ErrIncompleteGenesisJson
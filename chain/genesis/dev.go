@@ -0,0 +1,58 @@
+package genesis
+
+import (
+	"math/big"
+
+	"github.com/zenon-network/go-zenon/wallet"
+)
+
+const (
+	DevChainIdentifier = 1337
+	// DevMnemonic is public, the dev keys must never be used outside of dev chains
+	DevMnemonic    = "test test test test test test test test test test test junk"
+	DevAccounts    = 10
+	DevPillarName  = "dev-pillar"
+	devZnnBalance  = 1000000 * 1e8
+	devQsrBalance  = 1000000 * 1e8
+	devFusedAmount = 10000 * 1e8
+)
+
+// DevKeyPairs returns the first DevAccounts addresses of DevMnemonic, the first one produces the momentums of the dev chain
+func DevKeyPairs() ([]*wallet.KeyPair, error) {
+	keyStore, err := wallet.NewKeyStoreFromMnemonic(DevMnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	keyPairs := make([]*wallet.KeyPair, DevAccounts)
+	for i := range keyPairs {
+		if _, keyPairs[i], err = keyStore.DeriveForIndexPath(uint32(i)); err != nil {
+			return nil, err
+		}
+	}
+	return keyPairs, nil
+}
+
+// NewDevGenesisConfig returns the genesis of a single-node chain with one pillar & prefunded accounts.
+// Every dev key has ZNN, QSR & fused plasma, the first one is also the pillar & the spork address.
+func NewDevGenesisConfig(timestamp int64) (*GenesisConfig, error) {
+	keyPairs, err := DevKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+	spec := &GenesisSpec{
+		ChainIdentifier:     DevChainIdentifier,
+		ExtraData:           "This is the genesis config of a dev chain",
+		GenesisTimestampSec: timestamp,
+		SporkAddress:        keyPairs[0].Address,
+		Pillars:             []*PillarSpec{{Name: DevPillarName, ProducerAddress: keyPairs[0].Address}},
+	}
+	for _, keyPair := range keyPairs {
+		spec.Allocations = append(spec.Allocations, &AllocationSpec{
+			Address:  keyPair.Address,
+			Znn:      big.NewInt(devZnnBalance),
+			Qsr:      big.NewInt(devQsrBalance),
+			FusedQsr: big.NewInt(devFusedAmount),
+		})
+	}
+	return NewGenesisConfigFromSpec(spec)
+}
@@ -0,0 +1,194 @@
+package genesis
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+)
+
+// ExportGenesisConfig turns the state of momentumStore into the GenesisConfig of a new chain.
+// A zero chainIdentifier or a nil sporkAddress keeps the values of the exported chain.
+//
+// The storage of the pillar, token, plasma, swap & spork contracts is exported. Stakes, sentinels & QSR deposits
+// are not part of a genesis, so the amounts locked by them are credited back to their owners. The balances of the
+// other embedded contracts are kept. Heights are rebased on the new chain, so activated sporks are enforced from the start.
+func ExportGenesisConfig(momentumStore store.Momentum, chainIdentifier uint64, sporkAddress *types.Address) (*GenesisConfig, error) {
+	frontier, err := momentumStore.GetFrontierMomentum()
+	if err != nil {
+		return nil, err
+	}
+	if chainIdentifier == 0 {
+		chainIdentifier = momentumStore.ChainIdentifier()
+	}
+	if sporkAddress == nil {
+		sporkAddress = momentumStore.GetSporkAddress()
+	}
+	rebase := func(height uint64) uint64 {
+		if height <= frontier.Height {
+			return 1
+		}
+		return height - frontier.Height + 1
+	}
+
+	config := &GenesisConfig{
+		ChainIdentifier:     chainIdentifier,
+		ExtraData:           fmt.Sprintf("fork of chain %v at momentum %v", momentumStore.ChainIdentifier(), frontier.Height),
+		GenesisTimestampSec: frontier.Timestamp.Unix(),
+		SporkAddress:        sporkAddress,
+		PillarConfig:        &PillarContractConfig{},
+		TokenConfig:         &TokenContractConfig{},
+		PlasmaConfig:        &PlasmaContractConfig{},
+		SwapConfig:          &SwapContractConfig{},
+		GenesisBlocks:       &GenesisBlocksConfig{},
+	}
+
+	pillarStorage := momentumStore.GetAccountStore(types.PillarContract).Storage()
+	if config.PillarConfig.Pillars, err = definition.GetPillarsList(pillarStorage, true, definition.AnyPillarType); err != nil {
+		return nil, err
+	}
+	if config.PillarConfig.Delegations, err = definition.GetDelegationsList(pillarStorage); err != nil {
+		return nil, err
+	}
+	if config.PillarConfig.LegacyEntries, err = definition.GetLegacyPillarList(pillarStorage); err != nil {
+		return nil, err
+	}
+	if config.PlasmaConfig.Fusions, err = definition.GetFusionInfoList(momentumStore.GetAccountStore(types.PlasmaContract).Storage()); err != nil {
+		return nil, err
+	}
+	for _, fusion := range config.PlasmaConfig.Fusions {
+		fusion.ExpirationHeight = rebase(fusion.ExpirationHeight)
+	}
+	if config.SwapConfig.Entries, err = definition.GetSwapAssets(momentumStore.GetAccountStore(types.SwapContract).Storage()); err != nil {
+		return nil, err
+	}
+	sporks, err := momentumStore.GetAllDefinedSporks()
+	if err != nil {
+		return nil, err
+	}
+	if len(sporks) != 0 {
+		for _, spork := range sporks {
+			if spork.Activated {
+				spork.EnforcementHeight = rebase(spork.EnforcementHeight)
+			}
+		}
+		config.SporkConfig = &SporkConfig{Sporks: sporks}
+	}
+
+	// the amounts locked by the contracts which aren't exported are returned to their owners
+	credits := make(map[types.Address]map[types.ZenonTokenStandard]*big.Int)
+	owners := make([]types.Address, 0)
+	credit := func(owner types.Address, zts types.ZenonTokenStandard, amount *big.Int) {
+		if amount == nil || amount.Sign() == 0 {
+			return
+		}
+		if _, ok := credits[owner]; !ok {
+			credits[owner] = make(map[types.ZenonTokenStandard]*big.Int)
+			owners = append(owners, owner)
+		}
+		if _, ok := credits[owner][zts]; !ok {
+			credits[owner][zts] = big.NewInt(0)
+		}
+		credits[owner][zts].Add(credits[owner][zts], amount)
+	}
+	if err := definition.IterateStakeEntries(momentumStore.GetAccountStore(types.StakeContract).Storage(), func(stake *definition.StakeInfo) error {
+		credit(stake.StakeAddress, types.ZnnTokenStandard, stake.Amount)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sentinelStorage := momentumStore.GetAccountStore(types.SentinelContract).Storage()
+	for _, sentinel := range definition.GetAllSentinelInfo(sentinelStorage) {
+		credit(sentinel.Owner, types.ZnnTokenStandard, sentinel.ZnnAmount)
+		credit(sentinel.Owner, types.QsrTokenStandard, sentinel.QsrAmount)
+	}
+	for _, storage := range []db.DB{pillarStorage, sentinelStorage} {
+		deposits, err := definition.GetQsrDepositList(storage)
+		if err != nil {
+			return nil, err
+		}
+		for _, deposit := range deposits {
+			credit(*deposit.Address, types.QsrTokenStandard, deposit.Qsr)
+		}
+	}
+
+	// the balances of these contracts must match their exported storage
+	pillarsAmount := big.NewInt(0)
+	for _, pillar := range config.PillarConfig.Pillars {
+		pillarsAmount.Add(pillarsAmount, pillar.Amount)
+	}
+	fusedAmount := big.NewInt(0)
+	for _, fusion := range config.PlasmaConfig.Fusions {
+		fusedAmount.Add(fusedAmount, fusion.Amount)
+	}
+	derived := map[types.Address]map[types.ZenonTokenStandard]*big.Int{
+		types.PillarContract: {types.ZnnTokenStandard: pillarsAmount},
+		types.PlasmaContract: {types.QsrTokenStandard: fusedAmount},
+		types.SwapContract:   {},
+		// credited to the owners
+		types.StakeContract:    {},
+		types.SentinelContract: {},
+	}
+
+	addresses, err := momentumStore.GetAllAccountAddresses()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[types.Address]bool, len(addresses))
+	for _, address := range addresses {
+		known[address] = true
+	}
+	for _, owner := range owners {
+		if !known[owner] {
+			addresses = append(addresses, owner)
+		}
+	}
+	supply := make(map[types.ZenonTokenStandard]*big.Int)
+	for _, address := range addresses {
+		balances, ok := derived[address]
+		if !ok {
+			if balances, err = momentumStore.GetAccountStore(address).GetBalanceMap(); err != nil {
+				return nil, err
+			}
+		}
+		balanceList := make(map[types.ZenonTokenStandard]*big.Int)
+		for _, amounts := range []map[types.ZenonTokenStandard]*big.Int{balances, credits[address]} {
+			for zts, amount := range amounts {
+				if amount.Sign() == 0 {
+					continue
+				}
+				if _, ok := balanceList[zts]; !ok {
+					balanceList[zts] = big.NewInt(0)
+				}
+				balanceList[zts].Add(balanceList[zts], amount)
+				if _, ok := supply[zts]; !ok {
+					supply[zts] = big.NewInt(0)
+				}
+				supply[zts].Add(supply[zts], amount)
+			}
+		}
+		if len(balanceList) != 0 {
+			config.GenesisBlocks.Blocks = append(config.GenesisBlocks.Blocks, &GenesisBlockConfig{
+				Address:     address,
+				BalanceList: balanceList,
+			})
+		}
+	}
+
+	// unreceived & dropped amounts are not part of the new total supply, tokens without holders are dropped
+	tokens, err := definition.GetTokenInfoList(momentumStore.GetAccountStore(types.TokenContract).Storage())
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		if total, ok := supply[token.TokenStandard]; ok {
+			token.TotalSupply = total
+			config.TokenConfig.Tokens = append(config.TokenConfig.Tokens, token)
+		}
+	}
+
+	return config, CheckGenesis(config)
+}
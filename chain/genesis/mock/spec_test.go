@@ -0,0 +1,80 @@
+package g
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/zenon-network/go-zenon/chain/genesis"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+func TestGenesisFromSpec(t *testing.T) {
+	config, err := genesis.NewGenesisConfigFromSpec(&genesis.GenesisSpec{
+		ChainIdentifier:     3,
+		GenesisTimestampSec: 1000000000,
+		SporkAddress:        Spork.Address,
+		Pillars: []*genesis.PillarSpec{
+			{Name: "pillar-1", ProducerAddress: Pillar1.Address},
+			{Name: "pillar-2", ProducerAddress: Pillar2.Address, OwnerAddress: User1.Address},
+		},
+		Allocations: []*genesis.AllocationSpec{
+			{Address: User1.Address, Znn: big.NewInt(1000), Qsr: big.NewInt(10000), FusedQsr: big.NewInt(100)},
+			{Address: User2.Address, Qsr: big.NewInt(5000)},
+			{Address: User1.Address, Znn: big.NewInt(500)},
+		},
+	})
+	common.FailIfErr(t, err)
+	common.Expect(t, len(genesis.ValidateGenesis(config)), 0)
+	common.ExpectString(t, config.PillarConfig.Pillars[1].StakeAddress.String(), User1.Address.String())
+	common.ExpectAmount(t, config.TokenConfig.Tokens[1].TotalSupply, big.NewInt(15100))
+	for _, block := range config.GenesisBlocks.Blocks {
+		if block.Address == User1.Address {
+			common.ExpectAmount(t, block.BalanceList[types.ZnnTokenStandard], big.NewInt(1500))
+		}
+	}
+}
+
+func TestGenesisFromInvalidSpec(t *testing.T) {
+	_, err := genesis.NewGenesisConfigFromSpec(&genesis.GenesisSpec{
+		ChainIdentifier: 3,
+		SporkAddress:    Spork.Address,
+		Pillars: []*genesis.PillarSpec{
+			{Name: "pillar-1", ProducerAddress: Pillar1.Address},
+			{Name: "pillar-2", ProducerAddress: Pillar1.Address},
+		},
+	})
+	common.ExpectString(t, err.Error(), "producer address "+Pillar1.Address.String()+" is used by 2 pillars")
+}
+
+func TestValidateGenesisListsAllProblems(t *testing.T) {
+	config, err := genesis.NewGenesisConfigFromSpec(&genesis.GenesisSpec{
+		ChainIdentifier: 3,
+		SporkAddress:    Spork.Address,
+		Pillars:         []*genesis.PillarSpec{{Name: "pillar-1", ProducerAddress: Pillar1.Address}},
+		Allocations:     []*genesis.AllocationSpec{{Address: User1.Address, Qsr: big.NewInt(10000), FusedQsr: big.NewInt(100)}},
+	})
+	common.FailIfErr(t, err)
+	config.TokenConfig.Tokens[0].TotalSupply = big.NewInt(1)
+	config.PlasmaConfig.Fusions[0].Amount = big.NewInt(99)
+
+	problems := genesis.ValidateGenesis(config)
+	common.Expect(t, len(problems), 2)
+	common.ExpectString(t, genesis.CheckGenesis(config).Error(), problems[0].Error())
+}
+
+func TestValidateGenesisIsStricterThanCheckGenesis(t *testing.T) {
+	config, err := genesis.NewGenesisConfigFromSpec(&genesis.GenesisSpec{
+		ChainIdentifier: 3,
+		SporkAddress:    Spork.Address,
+		Pillars:         []*genesis.PillarSpec{{Name: "pillar-1", ProducerAddress: Pillar1.Address}},
+		Allocations:     []*genesis.AllocationSpec{{Address: User1.Address, Qsr: big.NewInt(10000)}},
+	})
+	common.FailIfErr(t, err)
+	config.TokenConfig.Tokens[1].MaxSupply = big.NewInt(1)
+
+	common.FailIfErr(t, genesis.CheckGenesis(config))
+	problems := genesis.ValidateGenesis(config)
+	common.Expect(t, len(problems), 1)
+	common.ExpectString(t, problems[0].Error(), "TotalSupply of token "+config.TokenConfig.Tokens[1].TokenStandard.String()+" exceeds its MaxSupply")
+}
@@ -9,7 +9,21 @@ import (
 	"github.com/zenon-network/go-zenon/common/types"
 )
 
-func checkAccountBalance(g *GenesisConfig, addr types.Address, required map[types.ZenonTokenStandard]*big.Int) error {
+// problems collects every error found by a check, the Check* functions only return the first one
+type problems []error
+
+func (p *problems) add(format string, args ...interface{}) {
+	*p = append(*p, errors.Errorf(format, args...))
+}
+func (p problems) first() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return p[0]
+}
+
+func checkAccountBalance(g *GenesisConfig, addr types.Address, required map[types.ZenonTokenStandard]*big.Int) problems {
+	var p problems
 	// Check account balance for enough qsr
 	for _, block := range g.GenesisBlocks.Blocks {
 		if block.Address != addr {
@@ -19,80 +33,121 @@ func checkAccountBalance(g *GenesisConfig, addr types.Address, required map[type
 		for zts, amount := range block.BalanceList {
 			requiredAmount, ok := required[zts]
 			if ok == false {
-				return errors.Errorf("invalid balance for %v Extra token %v", addr, zts)
-			} else {
-				if requiredAmount.Cmp(amount) != 0 {
-					return errors.Errorf("invalid balance for %v Expected %v %v but got %v", addr, requiredAmount, zts, amount)
-				}
+				p.add("invalid balance for %v Extra token %v", addr, zts)
+			} else if amount == nil || requiredAmount.Cmp(amount) != 0 {
+				p.add("invalid balance for %v Expected %v %v but got %v", addr, requiredAmount, zts, amount)
 			}
 		}
 
 		for token := range required {
 			_, ok := block.BalanceList[token]
 			if ok == false && required[token].Cmp(common.Big0) != 0 {
-				return errors.Errorf("invalid balance for %v Expected token %v to be present", addr, token)
+				p.add("invalid balance for %v Expected token %v to be present", addr, token)
 			}
 		}
 	}
 
-	return nil
+	return p
 }
 
-func CheckGenesis(g *GenesisConfig) error {
-	if err := CheckFieldsExist(g); err != nil {
-		return err
-	}
-	if err := CheckPlasmaInfo(g); err != nil {
-		return err
-	}
-	if err := CheckSwapAccount(g); err != nil {
-		return err
-	}
-	if err := CheckPillarBalance(g); err != nil {
-		return err
-	}
-	if err := CheckTokenTotalSupply(g); err != nil {
-		return err
+func genesisProblems(g *GenesisConfig) problems {
+	if p := fieldsExistProblems(g); len(p) != 0 {
+		// the other checks can't run without all fields
+		return p
+	}
+	var p problems
+	p = append(p, plasmaInfoProblems(g)...)
+	p = append(p, swapAccountProblems(g)...)
+	p = append(p, pillarBalanceProblems(g)...)
+	p = append(p, tokenTotalSupplyProblems(g)...)
+	return p
+}
+
+// ValidateGenesis returns every problem which makes CheckGenesis fail, followed by the ones found by
+// the stricter checks of the genesis check command, or nil if g is valid
+func ValidateGenesis(g *GenesisConfig) []error {
+	p := genesisProblems(g)
+	if CheckFieldsExist(g) != nil {
+		return p
 	}
-	return nil
+	return append(p, balancesProblems(g)...)
+}
+func CheckGenesis(g *GenesisConfig) error {
+	return genesisProblems(g).first()
 }
 
-func CheckFieldsExist(g *GenesisConfig) error {
+func fieldsExistProblems(g *GenesisConfig) problems {
+	var p problems
 	if g.GenesisBlocks == nil {
-		return errors.Errorf("GenesisBlocks is nil")
+		p.add("GenesisBlocks is nil")
 	}
 	if g.TokenConfig == nil {
-		return errors.Errorf("TokenConfig is nil")
+		p.add("TokenConfig is nil")
 	}
 	if g.PillarConfig == nil {
-		return errors.Errorf("PillarConfig is nil")
+		p.add("PillarConfig is nil")
 	}
 	if g.SporkAddress == nil {
-		return errors.Errorf("SporkAddress is nil")
+		p.add("SporkAddress is nil")
 	}
 	if g.PlasmaConfig == nil {
-		return errors.Errorf("PlasmaConfig is nil")
+		p.add("PlasmaConfig is nil")
 	}
 	if g.SwapConfig == nil {
-		return errors.Errorf("SwapConfig is nil")
+		p.add("SwapConfig is nil")
 	}
-	return nil
+	return p
 }
-func CheckPlasmaInfo(g *GenesisConfig) error {
+func CheckFieldsExist(g *GenesisConfig) error {
+	return fieldsExistProblems(g).first()
+}
+
+// balancesProblems finds the duplicated blocks, negative balances & supplies which are only rejected by ValidateGenesis,
+// since CheckGenesis has to keep accepting the existing genesis files
+func balancesProblems(g *GenesisConfig) problems {
+	var p problems
+	seen := make(map[types.Address]bool)
+	for _, block := range g.GenesisBlocks.Blocks {
+		if seen[block.Address] {
+			p.add("duplicated genesis block for %v", block.Address)
+		}
+		seen[block.Address] = true
+		for zts, amount := range block.BalanceList {
+			if amount != nil && amount.Sign() == -1 {
+				p.add("invalid balance for %v Expected a positive amount of %v but got %v", block.Address, zts, amount)
+			}
+		}
+	}
+	for _, token := range g.TokenConfig.Tokens {
+		if token.MaxSupply == nil {
+			p.add("nil MaxSupply for token %v", token.TokenStandard)
+		} else if token.TotalSupply != nil && token.TotalSupply.Cmp(token.MaxSupply) == 1 {
+			p.add("TotalSupply of token %v exceeds its MaxSupply", token.TokenStandard)
+		}
+	}
+	return p
+}
+func plasmaInfoProblems(g *GenesisConfig) problems {
+	var p problems
 	totalAmount := big.NewInt(0)
 
 	for addr, fusion := range g.PlasmaConfig.Fusions {
-		if fusion == nil {
-			return errors.Errorf("nil FusionInfo for %v", addr)
+		if fusion == nil || fusion.Amount == nil {
+			p.add("nil FusionInfo for %v", addr)
+			continue
 		}
 		totalAmount.Add(totalAmount, fusion.Amount)
 	}
 
-	return checkAccountBalance(g, types.PlasmaContract, map[types.ZenonTokenStandard]*big.Int{
+	return append(p, checkAccountBalance(g, types.PlasmaContract, map[types.ZenonTokenStandard]*big.Int{
 		types.QsrTokenStandard: totalAmount,
-	})
+	})...)
 }
-func CheckSwapAccount(g *GenesisConfig) error {
+func CheckPlasmaInfo(g *GenesisConfig) error {
+	return plasmaInfoProblems(g).first()
+}
+func swapAccountProblems(g *GenesisConfig) problems {
+	var p problems
 	given := map[types.ZenonTokenStandard]*big.Int{
 		types.ZnnTokenStandard: big.NewInt(0),
 		types.QsrTokenStandard: big.NewInt(0),
@@ -100,27 +155,43 @@ func CheckSwapAccount(g *GenesisConfig) error {
 
 	for _, entry := range g.SwapConfig.Entries {
 		if entry.Qsr == nil || entry.Znn == nil {
-			return errors.Errorf("invalid swap balance for KeyIdHash %v", entry.KeyIdHash)
+			p.add("invalid swap balance for KeyIdHash %v", entry.KeyIdHash)
 		}
 	}
 
-	return checkAccountBalance(g, types.SwapContract, given)
+	return append(p, checkAccountBalance(g, types.SwapContract, given)...)
 }
-func CheckPillarBalance(g *GenesisConfig) error {
+func CheckSwapAccount(g *GenesisConfig) error {
+	return swapAccountProblems(g).first()
+}
+func pillarBalanceProblems(g *GenesisConfig) problems {
+	var p problems
 	totalAmount := big.NewInt(0)
 
 	for _, el := range g.PillarConfig.Pillars {
+		if el.Amount == nil {
+			p.add("nil Amount for pillar %v", el.Name)
+			continue
+		}
 		totalAmount.Add(totalAmount, el.Amount)
 	}
 
-	return checkAccountBalance(g, types.PillarContract, map[types.ZenonTokenStandard]*big.Int{
+	return append(p, checkAccountBalance(g, types.PillarContract, map[types.ZenonTokenStandard]*big.Int{
 		types.ZnnTokenStandard: totalAmount,
-	})
+	})...)
 }
-func CheckTokenTotalSupply(g *GenesisConfig) error {
+func CheckPillarBalance(g *GenesisConfig) error {
+	return pillarBalanceProblems(g).first()
+}
+func tokenTotalSupplyProblems(g *GenesisConfig) problems {
+	var p problems
 	given := make(map[types.ZenonTokenStandard]*big.Int)
 	for _, block := range g.GenesisBlocks.Blocks {
 		for zts, amount := range block.BalanceList {
+			if amount == nil {
+				p.add("nil balance of %v for %v", zts, block.Address)
+				continue
+			}
 			total, ok := given[zts]
 			if ok == false {
 				given[zts] = new(big.Int).Set(amount)
@@ -133,9 +204,9 @@ func CheckTokenTotalSupply(g *GenesisConfig) error {
 	for _, token := range g.TokenConfig.Tokens {
 		total, ok := given[token.TokenStandard]
 		if ok == false {
-			return errors.Errorf("token %v declared but not given at all", token)
-		} else if token.TotalSupply.Cmp(total) != 0 {
-			return errors.Errorf("invalid token total balance for %v Expected %v but got %v", token, total, token.TotalSupply)
+			p.add("token %v declared but not given at all", token.TokenStandard)
+		} else if token.TotalSupply == nil || token.TotalSupply.Cmp(total) != 0 {
+			p.add("invalid token total balance for %v Expected %v but got %v", token.TokenStandard, total, token.TotalSupply)
 		}
 	}
 
@@ -149,10 +220,13 @@ func CheckTokenTotalSupply(g *GenesisConfig) error {
 		}
 
 		if found == false {
-			return errors.Errorf("invalid token %v given but not declared", zts)
+			p.add("invalid token %v given but not declared", zts)
 		}
 	}
-	return nil
+	return p
+}
+func CheckTokenTotalSupply(g *GenesisConfig) error {
+	return tokenTotalSupplyProblems(g).first()
 }
 
 // CheckGenesisCheckSum ensures that the hash of the account blocks don't change during the build.
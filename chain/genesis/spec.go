@@ -0,0 +1,210 @@
+package genesis
+
+import (
+	"math/big"
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+)
+
+var (
+	// same as the mainnet genesis
+	specMaxSupply = big.NewInt(9007199254740991)
+
+	pillarNameRegexp = regexp.MustCompile("^([a-zA-Z0-9]+[-._]?)*[a-zA-Z0-9]$")
+)
+
+// GenesisSpec is the compact description of a genesis, expanded by NewGenesisConfigFromSpec.
+// All amounts are in base units.
+type GenesisSpec struct {
+	ChainIdentifier     uint64
+	ExtraData           string
+	GenesisTimestampSec int64
+	SporkAddress        types.Address
+	Pillars             []*PillarSpec
+	Allocations         []*AllocationSpec
+}
+
+// PillarSpec registers a pillar which stakes constants.PillarStakeAmount, the ZNN is added to the total supply.
+// The owner is the stake & reward address and delegates to the pillar, if it's zero, ProducerAddress is used.
+type PillarSpec struct {
+	Name            string
+	ProducerAddress types.Address
+	OwnerAddress    types.Address
+}
+
+// AllocationSpec gives Znn & Qsr to Address and fuses FusedQsr, in addition to Qsr, for its plasma.
+// Nil amounts are zero.
+type AllocationSpec struct {
+	Address  types.Address
+	Znn      *big.Int
+	Qsr      *big.Int
+	FusedQsr *big.Int
+}
+
+func (spec *GenesisSpec) check() error {
+	if spec.ChainIdentifier == 0 {
+		return errors.New("missing chain identifier")
+	}
+	if spec.SporkAddress.IsZero() {
+		return errors.New("missing spork address")
+	}
+	if len(spec.Pillars) == 0 {
+		return errors.New("at least one pillar is required to produce momentums")
+	}
+	names := make(map[string]bool)
+	producers := make(map[types.Address]bool)
+	for _, pillar := range spec.Pillars {
+		if len(pillar.Name) > constants.PillarNameLengthMax || !pillarNameRegexp.MatchString(pillar.Name) {
+			return errors.Errorf("invalid pillar name %q", pillar.Name)
+		}
+		if names[pillar.Name] {
+			return errors.Errorf("duplicated pillar name %v", pillar.Name)
+		}
+		if pillar.ProducerAddress.IsZero() {
+			return errors.Errorf("missing producer address of pillar %v", pillar.Name)
+		}
+		if producers[pillar.ProducerAddress] {
+			return errors.Errorf("producer address %v is used by 2 pillars", pillar.ProducerAddress)
+		}
+		names[pillar.Name], producers[pillar.ProducerAddress] = true, true
+	}
+	for _, allocation := range spec.Allocations {
+		if allocation.Address.IsZero() || types.IsEmbeddedAddress(allocation.Address) {
+			return errors.Errorf("invalid allocation address %v", allocation.Address)
+		}
+		for _, amount := range []*big.Int{allocation.Znn, allocation.Qsr, allocation.FusedQsr} {
+			if amount != nil && amount.Sign() == -1 {
+				return errors.Errorf("negative allocation for %v", allocation.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// NewGenesisConfigFromSpec expands spec into a GenesisConfig which passes CheckGenesis
+func NewGenesisConfigFromSpec(spec *GenesisSpec) (*GenesisConfig, error) {
+	if err := spec.check(); err != nil {
+		return nil, err
+	}
+	sporkAddress := spec.SporkAddress
+	config := &GenesisConfig{
+		ChainIdentifier:     spec.ChainIdentifier,
+		ExtraData:           spec.ExtraData,
+		GenesisTimestampSec: spec.GenesisTimestampSec,
+		SporkAddress:        &sporkAddress,
+		PillarConfig:        &PillarContractConfig{},
+		PlasmaConfig:        &PlasmaContractConfig{},
+		SwapConfig:          &SwapContractConfig{},
+		GenesisBlocks:       &GenesisBlocksConfig{},
+	}
+
+	// balances of each address, in the order of their first appearance
+	var addresses []types.Address
+	balances := make(map[types.Address]map[types.ZenonTokenStandard]*big.Int)
+	give := func(address types.Address, zts types.ZenonTokenStandard, amount *big.Int) {
+		if amount == nil || amount.Sign() == 0 {
+			return
+		}
+		balance, ok := balances[address]
+		if !ok {
+			balance = make(map[types.ZenonTokenStandard]*big.Int)
+			balances[address] = balance
+			addresses = append(addresses, address)
+		}
+		if _, ok := balance[zts]; !ok {
+			balance[zts] = big.NewInt(0)
+		}
+		balance[zts].Add(balance[zts], amount)
+	}
+
+	for _, pillar := range spec.Pillars {
+		owner := pillar.OwnerAddress
+		if owner.IsZero() {
+			owner = pillar.ProducerAddress
+		}
+		config.PillarConfig.Pillars = append(config.PillarConfig.Pillars, &definition.PillarInfo{
+			Name:                         pillar.Name,
+			BlockProducingAddress:        pillar.ProducerAddress,
+			StakeAddress:                 owner,
+			RewardWithdrawAddress:        owner,
+			Amount:                       new(big.Int).Set(constants.PillarStakeAmount),
+			RegistrationTime:             spec.GenesisTimestampSec,
+			GiveBlockRewardPercentage:    0,
+			GiveDelegateRewardPercentage: 100,
+			PillarType:                   definition.LegacyPillarType,
+		})
+		config.PillarConfig.Delegations = append(config.PillarConfig.Delegations, &definition.DelegationInfo{
+			Name:   pillar.Name,
+			Backer: owner,
+		})
+		give(types.PillarContract, types.ZnnTokenStandard, constants.PillarStakeAmount)
+	}
+	for _, allocation := range spec.Allocations {
+		give(allocation.Address, types.ZnnTokenStandard, allocation.Znn)
+		give(allocation.Address, types.QsrTokenStandard, allocation.Qsr)
+		if allocation.FusedQsr != nil && allocation.FusedQsr.Sign() != 0 {
+			config.PlasmaConfig.Fusions = append(config.PlasmaConfig.Fusions, &definition.FusionInfo{
+				Owner:       allocation.Address,
+				Id:          types.NewHash(append(allocation.Address.Bytes(), common.Uint64ToBytes(uint64(len(config.PlasmaConfig.Fusions)))...)),
+				Amount:      new(big.Int).Set(allocation.FusedQsr),
+				Beneficiary: allocation.Address,
+			})
+			give(types.PlasmaContract, types.QsrTokenStandard, allocation.FusedQsr)
+		}
+	}
+
+	supply := map[types.ZenonTokenStandard]*big.Int{
+		types.ZnnTokenStandard: big.NewInt(0),
+		types.QsrTokenStandard: big.NewInt(0),
+	}
+	for _, address := range addresses {
+		for zts, amount := range balances[address] {
+			supply[zts].Add(supply[zts], amount)
+		}
+		config.GenesisBlocks.Blocks = append(config.GenesisBlocks.Blocks, &GenesisBlockConfig{
+			Address:     address,
+			BalanceList: balances[address],
+		})
+	}
+	if supply[types.QsrTokenStandard].Sign() == 0 {
+		return nil, errors.New("at least one allocation must have QSR, it's required by the QSR token")
+	}
+	config.TokenConfig = &TokenContractConfig{
+		Tokens: []*definition.TokenInfo{
+			{
+				Owner:         types.PillarContract,
+				TokenName:     "Zenon Coin",
+				TokenSymbol:   "ZNN",
+				TokenDomain:   "zenon.network",
+				TotalSupply:   supply[types.ZnnTokenStandard],
+				MaxSupply:     new(big.Int).Set(specMaxSupply),
+				Decimals:      8,
+				IsMintable:    true,
+				IsBurnable:    true,
+				IsUtility:     true,
+				TokenStandard: types.ZnnTokenStandard,
+			},
+			{
+				Owner:         types.StakeContract,
+				TokenName:     "QuasarCoin",
+				TokenSymbol:   "QSR",
+				TokenDomain:   "zenon.network",
+				TotalSupply:   supply[types.QsrTokenStandard],
+				MaxSupply:     new(big.Int).Set(specMaxSupply),
+				Decimals:      8,
+				IsMintable:    true,
+				IsBurnable:    true,
+				IsUtility:     true,
+				TokenStandard: types.QsrTokenStandard,
+			},
+		},
+	}
+
+	return config, CheckGenesis(config)
+}
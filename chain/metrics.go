@@ -0,0 +1,11 @@
+package chain
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// the counters are always collected, so they are served even if the node wasn't started with --metrics
+var (
+	insertedMomentumsCounter = metrics.NewRegisteredCounterForced("chain/momentums/inserted", nil)
+	deletedMomentumsCounter  = metrics.NewRegisteredCounterForced("chain/momentums/rollbacks", nil)
+)
@@ -26,3 +26,24 @@ func (ms *momentumStore) getZnnBalance(address types.Address) (*big.Int, error)
 func (ms *momentumStore) setZnnBalance(address types.Address, balance *big.Int) error {
 	return ms.DB.Put(getAccountZNNBalance(address), common.BigIntToBytes(balance))
 }
+
+// every account which has blocks has a ZNN balance entry, even if the balance is zero
+func (ms *momentumStore) GetAllAccountAddresses() ([]types.Address, error) {
+	iterator := ms.DB.NewIterator(accountZNNBalancePrefix)
+	defer iterator.Release()
+	addresses := make([]types.Address, 0)
+	for {
+		if !iterator.Next() {
+			if iterator.Error() != nil {
+				return nil, iterator.Error()
+			}
+			break
+		}
+		address, err := types.BytesToAddress(iterator.Key()[len(accountZNNBalancePrefix):])
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
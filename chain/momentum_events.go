@@ -21,6 +21,7 @@ func (em *momentumEventManager) broadcastInsertMomentum(detailed *nom.DetailedMo
 	em.changes.Lock()
 	defer em.changes.Unlock()
 
+	insertedMomentumsCounter.Inc(1)
 	for _, listener := range em.listeners {
 		listener.InsertMomentum(detailed)
 	}
@@ -29,6 +30,7 @@ func (em *momentumEventManager) broadcastDeleteMomentum(detailed *nom.DetailedMo
 	em.changes.Lock()
 	defer em.changes.Unlock()
 
+	deletedMomentumsCounter.Inc(1)
 	for _, listener := range em.listeners {
 		listener.DeleteMomentum(detailed)
 	}
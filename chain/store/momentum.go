@@ -48,6 +48,8 @@ type Momentum interface {
 	ComputePillarDelegations() ([]*types.PillarDelegationDetail, error)
 
 	GetAccountStore(address types.Address) Account
+	// GetAllAccountAddresses returns the addresses of all accounts which have at least one account-block
+	GetAllAccountAddresses() ([]types.Address, error)
 	GetAccountDB(address types.Address) db.DB
 	GetAccountMailbox(address types.Address) AccountMailbox
 
@@ -0,0 +1,12 @@
+package db
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// counters of the rollback caches used by ldbManager to rebuild past versions of the db, always collected
+var (
+	l1CacheHitCounter = metrics.NewRegisteredCounterForced("db/cache/l1/hits", nil)
+	l2CacheHitCounter = metrics.NewRegisteredCounterForced("db/cache/l2/hits", nil)
+	cacheMissCounter  = metrics.NewRegisteredCounterForced("db/cache/misses", nil)
+)
@@ -235,18 +235,25 @@ func (m *ldbManager) Get(identifier types.HashHeight) DB {
 	var toIdentifier types.HashHeight
 
 	if cache, ok := m.l1Cache.Get(identifier); ok {
+		l1CacheHitCounter.Inc(1)
 		toIdentifier = cache.(*rollbackCache).frontier
 		rawChanges = cache.(*rollbackCache).raw
 	} else if cache, ok := m.l2Cache.Get(identifier); ok {
+		l2CacheHitCounter.Inc(1)
 		toIdentifier = cache.(*rollbackCache).frontier
 		rawChanges = cache.(*rollbackCache).raw
 	} else {
+		cacheMissCounter.Inc(1)
 		rawChanges = newMemDBInternal()
 		toIdentifier = identifier
 	}
 
 	for i := toIdentifier.Height + 1; i <= frontierIdentifier.Height; i += 1 {
 		rollback := m.getRollback(i)
+		if rollback == nil {
+			// the rollback patch was discarded, the version can't be rebuilt anymore
+			return nil
+		}
 		if err := ApplyWithoutOverride(rawChanges, rollback); err != nil {
 			common.DealWithErr(err)
 		}
@@ -3,6 +3,7 @@ package common
 import (
 	"bytes"
 	"path/filepath"
+	"sync/atomic"
 
 	"github.com/inconshreveable/log15"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -28,18 +29,23 @@ var (
 	SupervisorLogger = log15.New("module", "supervisor")
 	EmbeddedLogger   = log15.New("module", "embedded")
 	WalletLogger     = log15.New("module", "wallet")
+	IndexerLogger    = log15.New("module", "indexer")
+	ReceiverLogger   = log15.New("module", "receiver")
+	WebhookLogger    = log15.New("module", "webhook")
 )
 
+// logLevel is the max level written to the run log, it can be changed at runtime with SetLogLevel
+var logLevel = int32(log15.LvlInfo)
+
 func InitLogging(dataPath, logLevelStr string) {
 	var logHandle []log15.Handler
 
 	logDir := runLogDir(dataPath)
-	logLevel, err := log15.LvlFromString(logLevelStr)
-	if err != nil {
-		logLevel = log15.LvlInfo
+	if err := SetLogLevel(logLevelStr); err != nil {
+		atomic.StoreInt32(&logLevel, int32(log15.LvlInfo))
 	}
 
-	logHandle = append(logHandle, errorExcludeLvlFilterHandler(logLevel, runLogHandler(logDir)))
+	logHandle = append(logHandle, errorExcludeLvlFilterHandler(runLogHandler(logDir)))
 	logHandle = append(logHandle, log15.LvlFilterHandler(log15.LvlError, runErrorLogHandler(logDir)))
 
 	log15.Root().SetHandler(log15.MultiHandler(
@@ -47,6 +53,19 @@ func InitLogging(dataPath, logLevelStr string) {
 	))
 }
 
+// SetLogLevel changes the level of the run log, it accepts the same values as the LogLevel config
+func SetLogLevel(logLevelStr string) error {
+	lvl, err := log15.LvlFromString(logLevelStr)
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt32(&logLevel, int32(lvl))
+	return nil
+}
+func GetLogLevel() string {
+	return log15.Lvl(atomic.LoadInt32(&logLevel)).String()
+}
+
 func runLogDir(dataPath string) string {
 	return filepath.Join(dataPath, "log")
 }
@@ -60,9 +79,9 @@ func runErrorLogHandler(logDir string) log15.Handler {
 	logger := defaultLogger(filepath.Join(logDir, "error", filename))
 	return log15.StreamHandler(logger, log15.LogfmtFormat())
 }
-func errorExcludeLvlFilterHandler(maxLvl log15.Lvl, h log15.Handler) log15.Handler {
+func errorExcludeLvlFilterHandler(h log15.Handler) log15.Handler {
 	return log15.FilterHandler(func(r *log15.Record) (ss bool) {
-		return r.Lvl <= maxLvl
+		return r.Lvl <= log15.Lvl(atomic.LoadInt32(&logLevel))
 	}, h)
 }
 func defaultLogger(absFilePath string) *lumberjack.Logger {
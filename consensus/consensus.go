@@ -49,14 +49,17 @@ func (cs *consensus) FixedPillarReader(identifier types.HashHeight) api.PillarRe
 	}
 }
 
-// NewConsensus instantiates a new consensus object
-func NewConsensus(db db.DB, chain chain.Chain, testing bool) Consensus {
+// NewConsensus instantiates a new consensus object, if config is nil constants.ConsensusConfig is used
+func NewConsensus(db db.DB, chain chain.Chain, config *constants.Consensus, testing bool) Consensus {
+	if config == nil {
+		config = constants.ConsensusConfig
+	}
 	genesisTimestamp := chain.GetGenesisMomentum().Timestamp
 	epochTicker := common.NewTicker(*genesisTimestamp, EpochDuration)
-	cacheSize := 7 * 24 * 60 * 60 / (constants.ConsensusConfig.BlockTime * int64(constants.ConsensusConfig.NodeCount))
+	cacheSize := 7 * 24 * 60 * 60 / (config.BlockTime * int64(config.NodeCount))
 
 	dbCache := storage.NewConsensusDB(db, int(cacheSize), int(cacheSize))
-	electionManager := newElectionManager(chain, dbCache)
+	electionManager := newElectionManager(chain, dbCache, config)
 
 	return &consensus{
 		log:             common.ConsensusLogger,
@@ -72,7 +72,7 @@ func TestAlgo_fillPillars(t *testing.T) {
 		RandCount:   2,
 		CountingZTS: types.ZnnTokenStandard,
 	}
-	smallCG := NewConsensusContext(time.Unix(2000000000, 0))
+	smallCG := NewConsensusContext(time.Unix(2000000000, 0), constants.ConsensusConfig)
 	for numPillars := 1; numPillars <= 5; numPillars++ {
 		delegations := generateDelegationInfo(numPillars)
 		numIterations := 12000
@@ -101,7 +101,7 @@ func TestAlgo_seed(t *testing.T) {
 		RandCount:   2,
 		CountingZTS: types.ZnnTokenStandard,
 	}
-	smallCG := NewConsensusContext(time.Unix(2000000000, 0))
+	smallCG := NewConsensusContext(time.Unix(2000000000, 0), constants.ConsensusConfig)
 	ag := NewElectionAlgorithm(smallCG)
 
 	numPillars := 3
@@ -129,7 +129,7 @@ func TestAlgo_bigDistribution(t *testing.T) {
 		RandCount:   15,
 		CountingZTS: types.ZnnTokenStandard,
 	}
-	cg := NewConsensusContext(time.Unix(2000000000, 0))
+	cg := NewConsensusContext(time.Unix(2000000000, 0), constants.ConsensusConfig)
 
 	for _, numPillars := range []int{31, 50, 75, 100, 150} {
 		delegations := generateDelegationInfo(numPillars)
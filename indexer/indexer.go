@@ -0,0 +1,286 @@
+package indexer
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+const (
+	DirectionIncoming uint8 = 1
+	DirectionOutgoing uint8 = 2
+)
+
+const (
+	// queries are answered if the indexes are at most maxQueryLag momentums behind the frontier
+	maxQueryLag = 10
+	// the frontier is checked for rollbacks & Stop after every syncBatchSize momentums
+	syncBatchSize = 1000
+)
+
+var (
+	ErrInvalidDirection   = errors.New("invalid direction")
+	ErrIndexerStopped     = errors.New("indexer is stopped")
+	ErrIndexingInProgress = errors.New("indexing in progress, the account-blocks are not indexed up to the frontier momentum yet")
+)
+
+// Indexer keeps secondary indexes of confirmed account-blocks, by owner address,
+// in order to answer queries which can't be answered by walking one account-chain.
+type Indexer interface {
+	// MomentumEventListener is used to wake up the indexer when the frontier changes
+	chain.MomentumEventListener
+
+	Init() error
+	Start() error
+	Stop() error
+
+	// GetAccountBlocksByFilter returns the hashes of the matching account-blocks, newest first, and the number of matches
+	// found up to the end of the page, plus one if there are more. Returns ErrIndexingInProgress if the indexes are behind the frontier.
+	GetAccountBlocksByFilter(filter *Filter, pageIndex, pageSize uint32) ([]types.Hash, int, error)
+	// Frontier returns the last indexed momentum
+	Frontier() (types.HashHeight, error)
+}
+
+// Filter is applied on account-blocks owned by Address. All other fields are optional.
+// For receive-blocks, Counterparty & TokenStandard refer to the paired send-block.
+type Filter struct {
+	Address       types.Address
+	Counterparty  *types.Address
+	TokenStandard *types.ZenonTokenStandard
+	BlockType     *uint64
+	Direction     *uint8
+	FromTimestamp *int64 // inclusive
+	ToTimestamp   *int64 // exclusive
+}
+
+// indexer updates the indexes from a single routine. Queries don't need to lock them,
+// since every momentum is written in one batch.
+type indexer struct {
+	log     common.Logger
+	chain   chain.Chain
+	storage *storage
+
+	wake   chan struct{}
+	closed chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewIndexer(db *leveldb.DB, chain chain.Chain) Indexer {
+	return &indexer{
+		log:     common.IndexerLogger,
+		chain:   chain,
+		storage: &storage{db: db},
+		wake:    make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (i *indexer) Init() error {
+	removed, err := i.storage.checkVersion()
+	if err != nil {
+		return err
+	}
+	if removed {
+		i.log.Info("removed indexes with an old format, the chain is indexed again")
+	}
+	return nil
+}
+func (i *indexer) Start() error {
+	i.chain.Register(i)
+
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		for {
+			done, err := i.sync()
+			if err != nil {
+				i.log.Error("failed to sync indexer", "reason", err)
+			}
+			if !done && err == nil {
+				i.notify()
+			}
+
+			select {
+			case <-i.wake:
+			case <-i.closed:
+				return
+			}
+		}
+	}()
+	return nil
+}
+func (i *indexer) Stop() error {
+	i.chain.UnRegister(i)
+	close(i.closed)
+	i.wg.Wait()
+	return i.storage.db.Close()
+}
+
+// InsertMomentum & DeleteMomentum only wake the indexer, since the chain insert-lock is held while
+// the events are broadcast. Rollbacks are detected in sync by comparing the indexed momentums with the chain.
+func (i *indexer) InsertMomentum(*nom.DetailedMomentum) {
+	i.notify()
+}
+func (i *indexer) DeleteMomentum(*nom.DetailedMomentum) {
+	i.notify()
+}
+func (i *indexer) notify() {
+	select {
+	case i.wake <- struct{}{}:
+	default:
+	}
+}
+
+// sync brings the indexes closer to the frontier momentum, by at most syncBatchSize momentums.
+// Momentums which are no longer part of the chain are removed first. Returns true if the frontier is reached.
+func (i *indexer) sync() (bool, error) {
+	store := i.chain.GetFrontierMomentumStore()
+	frontierMomentum, err := store.GetFrontierMomentum()
+	if err != nil {
+		return false, err
+	}
+	if frontierMomentum == nil {
+		return true, nil
+	}
+
+	indexed, err := i.storage.getFrontier()
+	if err != nil {
+		return false, err
+	}
+	for indexed.Height != 0 {
+		momentum, err := store.GetMomentumByHeight(indexed.Height)
+		if err != nil {
+			return false, err
+		}
+		if momentum != nil && momentum.Hash == indexed.Hash {
+			break
+		}
+		i.log.Info("removing rolled back momentum", "identifier", indexed)
+		if err := i.storage.popMomentum(indexed); err != nil {
+			return false, err
+		}
+		if indexed, err = i.storage.getFrontier(); err != nil {
+			return false, err
+		}
+	}
+
+	last := frontierMomentum.Height
+	if last > indexed.Height+syncBatchSize {
+		last = indexed.Height + syncBatchSize
+	}
+	for height := indexed.Height + 1; height <= last; height += 1 {
+		momentum, err := store.GetMomentumByHeight(height)
+		if err != nil {
+			return false, err
+		}
+		detailed, err := store.PrefetchMomentum(momentum)
+		if err != nil {
+			return false, err
+		}
+		if err := i.storage.addMomentum(detailed, store.GetAccountBlockByHash); err != nil {
+			return false, err
+		}
+		if height%10000 == 0 {
+			i.log.Info("indexed momentums", "height", height, "frontier-height", frontierMomentum.Height)
+		}
+	}
+	return last == frontierMomentum.Height, nil
+}
+
+func (i *indexer) Frontier() (types.HashHeight, error) {
+	return i.storage.getFrontier()
+}
+
+func (i *indexer) GetAccountBlocksByFilter(filter *Filter, pageIndex, pageSize uint32) ([]types.Hash, int, error) {
+	if filter.Direction != nil && *filter.Direction != DirectionIncoming && *filter.Direction != DirectionOutgoing {
+		return nil, 0, ErrInvalidDirection
+	}
+	select {
+	case <-i.closed:
+		return nil, 0, ErrIndexerStopped
+	default:
+	}
+
+	indexed, err := i.storage.getFrontier()
+	if err != nil {
+		return nil, 0, err
+	}
+	frontierMomentum, err := i.chain.GetFrontierMomentumStore().GetFrontierMomentum()
+	if err != nil {
+		return nil, 0, err
+	}
+	if indexed.Height+maxQueryLag < frontierMomentum.Height {
+		return nil, 0, ErrIndexingInProgress
+	}
+
+	// pick the most selective index
+	var prefix []byte
+	switch {
+	case filter.Counterparty != nil:
+		prefix = common.JoinBytes(counterpartyPrefix, filter.Address.Bytes(), filter.Counterparty.Bytes())
+	case filter.TokenStandard != nil:
+		prefix = common.JoinBytes(tokenPrefix, filter.Address.Bytes(), filter.TokenStandard.Bytes())
+	case filter.BlockType != nil:
+		prefix = common.JoinBytes(blockTypePrefix, filter.Address.Bytes(), common.Uint64ToBytes(*filter.BlockType))
+	default:
+		prefix = common.JoinBytes(addressPrefix, filter.Address.Bytes())
+	}
+
+	// the keys end with the timestamp & height, so they are iterated newest first until the page is filled.
+	// One more match is searched to know if there are more pages.
+	limit := (uint64(pageIndex)+1)*uint64(pageSize) + 1
+	matches := make([]types.Hash, 0)
+	iterator := i.storage.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iterator.Release()
+	for ok := iterator.Last(); ok && uint64(len(matches)) < limit; ok = iterator.Prev() {
+		e, err := parseEntry(filter.Address, iterator.Key(), iterator.Value())
+		if err != nil {
+			return nil, 0, err
+		}
+		if filter.matches(e) {
+			matches = append(matches, e.Hash)
+		}
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, 0, err
+	}
+
+	start := uint64(pageIndex) * uint64(pageSize)
+	if start > uint64(len(matches)) {
+		start = uint64(len(matches))
+	}
+	end := start + uint64(pageSize)
+	if end > uint64(len(matches)) {
+		end = uint64(len(matches))
+	}
+	return matches[start:end], len(matches), nil
+}
+
+func (f *Filter) matches(e *entry) bool {
+	if f.Counterparty != nil && *f.Counterparty != e.Counterparty {
+		return false
+	}
+	if f.TokenStandard != nil && *f.TokenStandard != e.TokenStandard {
+		return false
+	}
+	if f.BlockType != nil && *f.BlockType != e.BlockType {
+		return false
+	}
+	if f.Direction != nil && *f.Direction != e.Direction {
+		return false
+	}
+	if f.FromTimestamp != nil && e.Timestamp < *f.FromTimestamp {
+		return false
+	}
+	if f.ToTimestamp != nil && e.Timestamp >= *f.ToTimestamp {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,276 @@
+package indexer
+
+import (
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+var (
+	frontierKey = []byte{0}
+	versionKey  = []byte{6}
+
+	momentumPrefix     = []byte{1}
+	addressPrefix      = []byte{2}
+	counterpartyPrefix = []byte{3}
+	tokenPrefix        = []byte{4}
+	blockTypePrefix    = []byte{5}
+)
+
+const (
+	// storageVersion is increased when the format of the keys or records changes
+	storageVersion = 1
+
+	// size of timestamp + momentum-height + block-hash, which ends every entry key
+	entrySuffixSize = 8 + 8 + types.HashSize
+	// size of direction + block-type + counterparty + token-standard
+	recordSize = 1 + 8 + types.AddressSize + types.ZenonTokenStandardSize
+)
+
+// entry is the indexed view of one account-block, as seen from the account which owns it.
+type entry struct {
+	Address        types.Address
+	Hash           types.Hash
+	MomentumHeight uint64
+	Timestamp      int64
+
+	Direction     uint8
+	BlockType     uint64
+	Counterparty  types.Address
+	TokenStandard types.ZenonTokenStandard
+}
+
+func (e *entry) suffix() []byte {
+	return common.JoinBytes(common.Uint64ToBytes(uint64(e.Timestamp)), common.Uint64ToBytes(e.MomentumHeight), e.Hash.Bytes())
+}
+func (e *entry) record() []byte {
+	return common.JoinBytes([]byte{e.Direction}, common.Uint64ToBytes(e.BlockType), e.Counterparty.Bytes(), e.TokenStandard.Bytes())
+}
+func (e *entry) keys() [][]byte {
+	suffix := e.suffix()
+	return [][]byte{
+		common.JoinBytes(addressPrefix, e.Address.Bytes(), suffix),
+		common.JoinBytes(counterpartyPrefix, e.Address.Bytes(), e.Counterparty.Bytes(), suffix),
+		common.JoinBytes(tokenPrefix, e.Address.Bytes(), e.TokenStandard.Bytes(), suffix),
+		common.JoinBytes(blockTypePrefix, e.Address.Bytes(), common.Uint64ToBytes(e.BlockType), suffix),
+	}
+}
+
+// parseEntry rebuilds an entry from any of its keys & the record stored under it
+func parseEntry(address types.Address, key, record []byte) (*entry, error) {
+	if len(key) < entrySuffixSize || len(record) != recordSize {
+		return nil, errors.Errorf("malformed indexer entry")
+	}
+	suffix := key[len(key)-entrySuffixSize:]
+	e := &entry{
+		Address:        address,
+		Timestamp:      int64(common.BytesToUint64(suffix[:8])),
+		MomentumHeight: common.BytesToUint64(suffix[8:16]),
+		Direction:      record[0],
+		BlockType:      common.BytesToUint64(record[1:9]),
+	}
+	if err := e.Hash.SetBytes(suffix[16:]); err != nil {
+		return nil, err
+	}
+	if err := e.Counterparty.SetBytes(record[9 : 9+types.AddressSize]); err != nil {
+		return nil, err
+	}
+	if err := e.TokenStandard.SetBytes(record[9+types.AddressSize:]); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// momentumRecord remembers which entries were added by a momentum, so they can be removed on rollback.
+type momentumRecord struct {
+	Identifier types.HashHeight
+	Entries    []*entry
+}
+
+func (r *momentumRecord) serialize() []byte {
+	data := common.JoinBytes(r.Identifier.Hash.Bytes())
+	for _, e := range r.Entries {
+		data = common.JoinBytes(data, e.Address.Bytes(), e.record(), e.suffix())
+	}
+	return data
+}
+func parseMomentumRecord(height uint64, data []byte) (*momentumRecord, error) {
+	const size = types.AddressSize + recordSize + entrySuffixSize
+	if len(data) < types.HashSize || (len(data)-types.HashSize)%size != 0 {
+		return nil, errors.Errorf("malformed indexer momentum record at height %v", height)
+	}
+	r := &momentumRecord{
+		Identifier: types.HashHeight{Height: height},
+	}
+	if err := r.Identifier.Hash.SetBytes(data[:types.HashSize]); err != nil {
+		return nil, err
+	}
+	for current := data[types.HashSize:]; len(current) != 0; current = current[size:] {
+		address, err := types.BytesToAddress(current[:types.AddressSize])
+		if err != nil {
+			return nil, err
+		}
+		e, err := parseEntry(address, current[types.AddressSize+recordSize:size], current[types.AddressSize:types.AddressSize+recordSize])
+		if err != nil {
+			return nil, err
+		}
+		r.Entries = append(r.Entries, e)
+	}
+	return r, nil
+}
+
+type storage struct {
+	db *leveldb.DB
+}
+
+// checkVersion removes the indexes written with another format, they are rebuilt by the next sync
+func (s *storage) checkVersion() (bool, error) {
+	data, err := s.db.Get(versionKey, nil)
+	if err == nil && len(data) == 1 && data[0] == storageVersion {
+		return false, nil
+	}
+	if err != nil && err != leveldb.ErrNotFound {
+		return false, err
+	}
+
+	iterator := s.db.NewIterator(nil, nil)
+	defer iterator.Release()
+	batch := new(leveldb.Batch)
+	for iterator.Next() {
+		batch.Delete(append([]byte{}, iterator.Key()...))
+	}
+	if err := iterator.Error(); err != nil {
+		return false, err
+	}
+	batch.Put(versionKey, []byte{storageVersion})
+	return batch.Len() > 1, s.db.Write(batch, nil)
+}
+
+func (s *storage) getFrontier() (types.HashHeight, error) {
+	data, err := s.db.Get(frontierKey, nil)
+	if err == leveldb.ErrNotFound {
+		return types.HashHeight{}, nil
+	}
+	if err != nil {
+		return types.HashHeight{}, err
+	}
+	hash, err := types.BytesToHash(data[8:])
+	if err != nil {
+		return types.HashHeight{}, err
+	}
+	return types.HashHeight{
+		Height: common.BytesToUint64(data[:8]),
+		Hash:   hash,
+	}, nil
+}
+func setFrontier(batch *leveldb.Batch, identifier types.HashHeight) {
+	batch.Put(frontierKey, common.JoinBytes(common.Uint64ToBytes(identifier.Height), identifier.Hash.Bytes()))
+}
+func (s *storage) getMomentumRecord(height uint64) (*momentumRecord, error) {
+	data, err := s.db.Get(common.JoinBytes(momentumPrefix, common.Uint64ToBytes(height)), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseMomentumRecord(height, data)
+}
+
+// addMomentum indexes all account-blocks confirmed by detailed.
+// The entries & the new frontier are written in one batch, so readers never see a partially indexed momentum.
+func (s *storage) addMomentum(detailed *nom.DetailedMomentum, getBlock func(types.Hash) (*nom.AccountBlock, error)) error {
+	momentum := detailed.Momentum
+	record := &momentumRecord{
+		Identifier: momentum.Identifier(),
+		Entries:    make([]*entry, 0),
+	}
+
+	for _, block := range detailed.AccountBlocks {
+		blocks := append([]*nom.AccountBlock{block}, block.DescendantBlocks...)
+		for _, current := range blocks {
+			e, err := newEntry(momentum, current, getBlock)
+			if err != nil {
+				return err
+			}
+			record.Entries = append(record.Entries, e)
+		}
+	}
+
+	batch := new(leveldb.Batch)
+	for _, e := range record.Entries {
+		for _, key := range e.keys() {
+			batch.Put(key, e.record())
+		}
+	}
+	batch.Put(common.JoinBytes(momentumPrefix, common.Uint64ToBytes(momentum.Height)), record.serialize())
+	setFrontier(batch, momentum.Identifier())
+	return s.db.Write(batch, nil)
+}
+
+// popMomentum removes the entries added by the frontier momentum & moves the frontier to the previous one.
+func (s *storage) popMomentum(frontier types.HashHeight) error {
+	record, err := s.getMomentumRecord(frontier.Height)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return errors.Errorf("indexer is missing momentum record at height %v", frontier.Height)
+	}
+	batch := new(leveldb.Batch)
+	for _, e := range record.Entries {
+		for _, key := range e.keys() {
+			batch.Delete(key)
+		}
+	}
+
+	previous := types.HashHeight{}
+	if frontier.Height > 1 {
+		previousRecord, err := s.getMomentumRecord(frontier.Height - 1)
+		if err != nil {
+			return err
+		}
+		if previousRecord == nil {
+			return errors.Errorf("indexer is missing momentum record at height %v", frontier.Height-1)
+		}
+		previous = previousRecord.Identifier
+	}
+	setFrontier(batch, previous)
+	batch.Delete(common.JoinBytes(momentumPrefix, common.Uint64ToBytes(frontier.Height)))
+	return s.db.Write(batch, nil)
+}
+
+func newEntry(momentum *nom.Momentum, block *nom.AccountBlock, getBlock func(types.Hash) (*nom.AccountBlock, error)) (*entry, error) {
+	e := &entry{
+		Address:        block.Address,
+		Hash:           block.Hash,
+		MomentumHeight: momentum.Height,
+		Timestamp:      momentum.Timestamp.Unix(),
+		BlockType:      block.BlockType,
+	}
+
+	if block.IsSendBlock() {
+		e.Direction = DirectionOutgoing
+		e.Counterparty = block.ToAddress
+		e.TokenStandard = block.TokenStandard
+		return e, nil
+	}
+
+	e.Direction = DirectionIncoming
+	if block.BlockType == nom.BlockTypeGenesisReceive {
+		return e, nil
+	}
+	fromBlock, err := getBlock(block.FromBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if fromBlock == nil {
+		return nil, errors.Errorf("indexer can't find from-block %v of %v", block.FromBlockHash, block.Hash)
+	}
+	e.Counterparty = fromBlock.Address
+	e.TokenStandard = fromBlock.TokenStandard
+	return e, nil
+}
@@ -0,0 +1,245 @@
+package node
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/common"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+)
+
+const (
+	// minimum length of the HMAC secrets, in bytes
+	authSecretMinSize = 32
+	// max difference between the issued-at claim of a token without expiration & the local time
+	authIssuedAtWindow = 60 * time.Second
+)
+
+var (
+	ErrRPCUnauthorized = common.NewErrorWCode(-32001, "unauthorized")
+	ErrRPCForbidden    = common.NewErrorWCode(-32002, "forbidden")
+)
+
+// accessList matches methods against namespaces ("ledger", "embedded.pillar"), methods ("stats.syncInfo") or "*" for all of them
+type accessList struct {
+	all     bool
+	entries map[string]bool
+}
+
+func newAccessList(entries []string) accessList {
+	list := accessList{entries: make(map[string]bool, len(entries))}
+	for _, entry := range entries {
+		if entry == "*" {
+			list.all = true
+		}
+		list.entries[entry] = true
+	}
+	return list
+}
+func (l accessList) allows(method string) bool {
+	if l.all {
+		return true
+	}
+	// nested namespaces like "embedded.pillar" are matched by each of their prefixes
+	for name := method; ; {
+		if l.entries[name] {
+			return true
+		}
+		index := strings.LastIndex(name, ".")
+		if index == -1 {
+			return false
+		}
+		name = name[:index]
+	}
+}
+
+type authToken struct {
+	name   string
+	secret []byte
+	allow  accessList
+}
+
+// rpcAuth authenticates HTTP & WS requests using JWTs signed with HMAC-SHA256 (HS256) & checks every call
+// against the methods allowed for the token. Requests without a token can only call the public methods.
+type rpcAuth struct {
+	log    common.Logger
+	public accessList
+	tokens []*authToken
+}
+
+// newRPCAuth returns nil if auth is disabled. If config.Public is nil, the namespaces of the public apis are public.
+func newRPCAuth(config *RPCAuthConfig, apis []rpc.API) (*rpcAuth, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	public := config.Public
+	if public == nil {
+		public = []string{rpc.MetadataApi}
+		for _, api := range apis {
+			if api.Public {
+				public = append(public, api.Namespace)
+			}
+		}
+	}
+	auth := &rpcAuth{
+		log:    common.NodeLogger.New("submodule", "rpc-auth"),
+		public: newAccessList(public),
+	}
+	for _, tokenConfig := range config.Tokens {
+		secret, err := hex.DecodeString(strings.TrimPrefix(tokenConfig.Secret, "0x"))
+		if err != nil {
+			return nil, errors.Errorf("invalid secret for rpc token %v. Reason:%v", tokenConfig.Name, err)
+		}
+		if len(secret) < authSecretMinSize {
+			return nil, errors.Errorf("invalid secret for rpc token %v. Reason:expected at least %v bytes", tokenConfig.Name, authSecretMinSize)
+		}
+		auth.tokens = append(auth.tokens, &authToken{
+			name:   tokenConfig.Name,
+			secret: secret,
+			allow:  newAccessList(tokenConfig.Allow),
+		})
+	}
+	return auth, nil
+}
+
+// wrap adds the AccessFunc of the request to its context. A nil rpcAuth returns next unchanged.
+func (a *rpcAuth) wrap(next http.Handler) http.Handler {
+	if a == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		access, token := a.accessFor(r)
+		ctx := rpc.WithAccessFunc(r.Context(), access)
+		if token != nil {
+			// rate limits of authenticated clients are per token instead of per IP
+			ctx = rpc.WithClientName(ctx, token.name)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// accessFor never rejects the request itself, invalid tokens are reported as JSON-RPC errors to each call.
+// The token is nil if the request isn't authenticated. WS connections can only call the public methods once the token expires.
+func (a *rpcAuth) accessFor(r *http.Request) (rpc.AccessFunc, *authToken) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return a.check(nil, time.Time{}), nil
+	}
+	if !strings.HasPrefix(header, "Bearer ") {
+		return deny(ErrRPCUnauthorized.AddDetail("expected a bearer token")), nil
+	}
+	token, expiresAt, err := a.authenticate(strings.TrimPrefix(header, "Bearer "), time.Now())
+	if err != nil {
+		a.log.Debug("rejected rpc token", "remote", r.RemoteAddr, "reason", err)
+		return deny(ErrRPCUnauthorized.AddDetail(err.Error())), nil
+	}
+	a.log.Debug("authenticated rpc token", "remote", r.RemoteAddr, "token", token.name)
+	return a.check(token, expiresAt), token
+}
+
+// check only allows the public methods after expiresAt, unless it's zero
+func (a *rpcAuth) check(token *authToken, expiresAt time.Time) rpc.AccessFunc {
+	return func(method string) error {
+		if a.public.allows(method) {
+			return nil
+		}
+		if !expiresAt.IsZero() && !time.Now().Before(expiresAt) {
+			return ErrRPCUnauthorized.AddDetail("token is expired")
+		}
+		if token == nil {
+			return ErrRPCUnauthorized.AddDetail(fmt.Sprintf("method %v requires a token", method))
+		}
+		if token.allow.allows(method) {
+			return nil
+		}
+		return ErrRPCForbidden.AddDetail(fmt.Sprintf("method %v is not allowed for token %v", method, token.name))
+	}
+}
+
+func deny(err error) rpc.AccessFunc {
+	return func(string) error {
+		return err
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+type jwtClaims struct {
+	IssuedAt  *int64 `json:"iat"`
+	ExpiresAt *int64 `json:"exp"`
+}
+
+// authenticate finds the token whose secret signed jwt, checks its claims & returns the time when it expires.
+// Tokens with an expiration are valid until then. The others are only accepted within a short window around their
+// issued-at time & never expire, so a WS connection authenticated with them keeps its access.
+func (a *rpcAuth) authenticate(jwt string, now time.Time) (*authToken, time.Time, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return nil, time.Time{}, errors.New("malformed token")
+	}
+	var header jwtHeader
+	if err := decodeJwtPart(parts[0], &header); err != nil {
+		return nil, time.Time{}, err
+	}
+	if header.Alg != "HS256" {
+		return nil, time.Time{}, errors.Errorf("unsupported signing algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, time.Time{}, errors.New("malformed token signature")
+	}
+
+	var token *authToken
+	for _, t := range a.tokens {
+		mac := hmac.New(sha256.New, t.secret)
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		if hmac.Equal(mac.Sum(nil), signature) {
+			token = t
+			break
+		}
+	}
+	if token == nil {
+		return nil, time.Time{}, errors.New("invalid token signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeJwtPart(parts[1], &claims); err != nil {
+		return nil, time.Time{}, err
+	}
+	if claims.ExpiresAt != nil {
+		if now.Unix() >= *claims.ExpiresAt {
+			return nil, time.Time{}, errors.New("token is expired")
+		}
+		return token, time.Unix(*claims.ExpiresAt, 0), nil
+	}
+	if claims.IssuedAt == nil {
+		return nil, time.Time{}, errors.New("token has neither iat nor exp claims")
+	}
+	issuedAt := time.Unix(*claims.IssuedAt, 0)
+	if diff := now.Sub(issuedAt); diff > authIssuedAtWindow || diff < -authIssuedAtWindow {
+		return nil, time.Time{}, errors.New("token iat is too far from the current time")
+	}
+	return token, time.Time{}, nil
+}
+
+func decodeJwtPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.New("malformed token")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.New("malformed token")
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package node
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/common"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+)
+
+var (
+	authTestSecret      = strings.Repeat("11", 32)
+	authTestOtherSecret = strings.Repeat("22", 32)
+)
+
+type authTestApi struct{}
+
+func (authTestApi) Echo(message string) string {
+	return message
+}
+
+func makeTestJwt(secret string, claims map[string]int64) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	data, _ := json.Marshal(claims)
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	key, _ := hex.DecodeString(secret)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(header + "." + payload))
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// expectRPCError checks both the JSON-RPC code & message of err
+func expectRPCError(t *testing.T, err error, code int, message string) {
+	t.Helper()
+	rpcErr, ok := err.(rpc.Error)
+	if !ok {
+		t.Fatalf("expected a JSON-RPC error but got %v", err)
+	}
+	common.ExpectString(t, fmt.Sprintf("%v %v", rpcErr.ErrorCode(), rpcErr.Error()), fmt.Sprintf("%v %v", code, message))
+}
+
+func newAuthTestServer(t *testing.T) *httptest.Server {
+	apis := []rpc.API{
+		{Namespace: "ledger", Service: authTestApi{}, Public: true},
+		{Namespace: "stats", Service: authTestApi{}, Public: true},
+		{Namespace: "wallet", Service: authTestApi{}, Public: false},
+	}
+	auth, err := newRPCAuth(&RPCAuthConfig{
+		Tokens: []RPCTokenConfig{
+			{Name: "admin", Secret: authTestSecret, Allow: []string{"*"}},
+			{Name: "limited", Secret: "0x" + authTestOtherSecret, Allow: []string{"wallet.other"}},
+		},
+	}, apis)
+	common.FailIfErr(t, err)
+
+	srv := rpc.NewServer()
+	common.FailIfErr(t, RegisterApisFromWhitelist(apis, nil, srv, false, true))
+	ws := auth.wrap(srv.WebsocketHandler([]string{"*"}))
+	rpcHttp := auth.wrap(srv)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			ws.ServeHTTP(w, r)
+		} else {
+			rpcHttp.ServeHTTP(w, r)
+		}
+	}))
+}
+
+// - test that invalid secrets are rejected
+// - test that public namespaces don't require a token
+// - test that non-public namespaces require a token which allows them
+// - test invalid, expired & stale tokens
+func TestRPCAuth_HTTP(t *testing.T) {
+	_, err := newRPCAuth(&RPCAuthConfig{Tokens: []RPCTokenConfig{{Name: "short", Secret: "1111"}}}, nil)
+	common.ExpectString(t, err.Error(), "invalid secret for rpc token short. Reason:expected at least 32 bytes")
+
+	server := newAuthTestServer(t)
+	defer server.Close()
+	call := func(token, method string) error {
+		client, err := rpc.DialHTTP(server.URL)
+		common.FailIfErr(t, err)
+		defer client.Close()
+		if token != "" {
+			client.SetHeader("Authorization", "Bearer "+token)
+		}
+		var result string
+		if err := client.Call(&result, method, "message"); err != nil {
+			return err
+		}
+		common.ExpectString(t, result, "message")
+		return nil
+	}
+	now := time.Now().Unix()
+
+	common.FailIfErr(t, call("", "ledger.echo"))
+	expectRPCError(t, call("", "wallet.echo"), -32001, "unauthorized;method wallet.echo requires a token")
+	common.FailIfErr(t, call(makeTestJwt(authTestSecret, map[string]int64{"iat": now}), "wallet.echo"))
+	common.FailIfErr(t, call(makeTestJwt(authTestSecret, map[string]int64{"exp": now + 3600}), "wallet.echo"))
+	expectRPCError(t, call(makeTestJwt(authTestOtherSecret, map[string]int64{"iat": now}), "wallet.echo"), -32002, "forbidden;method wallet.echo is not allowed for token limited")
+
+	// invalid tokens are rejected even for public namespaces
+	expectRPCError(t, call(makeTestJwt(strings.Repeat("33", 32), map[string]int64{"iat": now}), "ledger.echo"), -32001, "unauthorized;invalid token signature")
+	expectRPCError(t, call(makeTestJwt(authTestSecret, map[string]int64{"iat": now - 3600}), "ledger.echo"), -32001, "unauthorized;token iat is too far from the current time")
+	expectRPCError(t, call(makeTestJwt(authTestSecret, map[string]int64{"iat": now - 7200, "exp": now - 3600}), "ledger.echo"), -32001, "unauthorized;token is expired")
+	expectRPCError(t, call("not-a-jwt", "ledger.echo"), -32001, "unauthorized;malformed token")
+}
+
+// - test that the token sent in the WS handshake applies to all calls on the connection
+// - test that a token without exp keeps its access after the iat window passes
+// - test that only the public methods can be called once the token expires
+func TestRPCAuth_WS(t *testing.T) {
+	server := newAuthTestServer(t)
+	defer server.Close()
+	endpoint := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	client, err := rpc.DialWebsocket(context.Background(), endpoint, "")
+	common.FailIfErr(t, err)
+	var result string
+	common.FailIfErr(t, client.Call(&result, "stats.echo", "message"))
+	expectRPCError(t, client.Call(&result, "wallet.echo", "message"), -32001, "unauthorized;method wallet.echo requires a token")
+	client.Close()
+
+	// the iat window passes while the connection is open
+	header := make(http.Header)
+	header.Set("Authorization", "Bearer "+makeTestJwt(authTestSecret, map[string]int64{"iat": time.Now().Unix() - int64(authIssuedAtWindow/time.Second) + 1}))
+	client, err = rpc.DialWebsocketWithHeader(context.Background(), endpoint, "", header)
+	common.FailIfErr(t, err)
+	defer client.Close()
+	common.FailIfErr(t, client.Call(&result, "wallet.echo", "message"))
+	common.ExpectString(t, result, "message")
+
+	header.Set("Authorization", "Bearer "+makeTestJwt(authTestSecret, map[string]int64{"exp": time.Now().Unix() + 1}))
+	expiring, err := rpc.DialWebsocketWithHeader(context.Background(), endpoint, "", header)
+	common.FailIfErr(t, err)
+	defer expiring.Close()
+	common.FailIfErr(t, expiring.Call(&result, "wallet.echo", "message"))
+	time.Sleep(2 * time.Second)
+	common.FailIfErr(t, client.Call(&result, "wallet.echo", "message"))
+	common.FailIfErr(t, client.Call(&result, "ledger.echo", "message"))
+	expectRPCError(t, expiring.Call(&result, "wallet.echo", "message"), -32001, "unauthorized;token is expired")
+	common.FailIfErr(t, expiring.Call(&result, "ledger.echo", "message"))
+	common.ExpectString(t, result, "message")
+}
@@ -1,9 +1,14 @@
 package node
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -12,7 +17,10 @@ import (
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/metadata"
 	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/receiver"
+	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
 	"github.com/zenon-network/go-zenon/wallet"
+	"github.com/zenon-network/go-zenon/webhook"
 	"github.com/zenon-network/go-zenon/zenon"
 )
 
@@ -22,6 +30,63 @@ type ProducerConfig struct {
 	KeyFilePath string
 	Password    string
 }
+
+// AutoReceiverConfig enables the generation of receive-blocks for Addresses, as long as they are unlocked in the wallet
+type AutoReceiverConfig struct {
+	Addresses      []string
+	TokenStandards []string // if empty, all tokens are received
+	MinAmount      string   // in the smallest unit of the token, if empty, all amounts are received
+}
+
+// WebhookConfig POSTs batches of the momentums & account-blocks matching Filter to URL.
+// Pending deliveries are queued in DataPath/webhooks & the ones which fail MaxAttempts times are appended to DataPath/webhooks/dead-letter.log
+type WebhookConfig struct {
+	Name      string                        // unique, identifies the queue of the webhook
+	URL       string                        //
+	Secret    string                        // hex encoded, signs the requests with HMAC-SHA256
+	Momentums bool                          // deliver every momentum, not only the ones with matching account-blocks
+	Filter    *subscribe.AccountBlockFilter // if nil, all account-blocks match
+
+	// if 0, the defaults of the webhook package are used
+	BatchSize      int
+	MaxAttempts    int
+	InitialBackoff int // in milliseconds
+	MaxBackoff     int // in milliseconds
+}
+
+// RPCAuthConfig enables bearer-token authentication on the HTTP & WS servers.
+// Clients send JWTs signed with HS256, using the secret of one of the Tokens, in the Authorization header.
+// Entries of Public & Allow are namespaces ("ledger"), methods ("stats.syncInfo") or "*" for all of them.
+type RPCAuthConfig struct {
+	Public []string // callable without a token, if nil, the public namespaces are used
+	Tokens []RPCTokenConfig
+}
+type RPCTokenConfig struct {
+	Name   string   // used in logs & errors
+	Secret string   // hex encoded, at least 32 bytes
+	Allow  []string // callable with this token, in addition to Public
+}
+
+// RPCRateLimitConfig limits the calls of each client of the HTTP & WS servers using token buckets.
+// Clients are identified by their remote IP, or by the name of their token if auth is enabled.
+// Every call costs its weight, HTTP requests of clients without budget left are rejected with 429.
+type RPCRateLimitConfig struct {
+	Rate  float64 // weight refilled per second
+	Burst float64 // max weight available at once
+
+	// limits of clients with a valid token, if 0, Rate & Burst are used
+	TokenRate  float64
+	TokenBurst float64
+
+	// weights of methods ("ledger.getDetailedMomentumsByHeight") or namespaces ("embedded.pillar"), merged with DefaultRPCWeights
+	Weights map[string]float64
+}
+
+// HealthConfig sets the thresholds of the /ready endpoint of the HTTP server
+type HealthConfig struct {
+	MinPeers          int   // if 0, Net.MinPeers is used
+	MaxMomentumAgeSec int64 // if 0, DefaultMaxMomentumAgeSec is used
+}
 type RPCConfig struct {
 	EnableHTTP bool
 	EnableWS   bool
@@ -36,6 +101,48 @@ type RPCConfig struct {
 	HTTPVirtualHosts []string
 	HTTPCors         []string
 	WSOrigins        []string
+
+	// IPCPath is the path of the unix socket, or the name of the named pipe on windows, which serves all APIs.
+	// Relative paths are placed in DataPath. If empty, IPC is disabled.
+	IPCPath string
+
+	// Auth restricts the HTTP & WS servers, if not nil. The non-public namespaces are only served if it's set.
+	Auth *RPCAuthConfig
+
+	// RateLimit limits the calls of each client of the HTTP & WS servers, if not nil
+	RateLimit *RPCRateLimitConfig
+
+	// Health configures the /health & /ready endpoints of the HTTP server
+	Health HealthConfig
+
+	// EnablePoW exposes ledger.generatePoW which computes PoW nonces on behalf of clients
+	EnablePoW bool
+
+	// EnableIndexer indexes the account-blocks by address, to serve ledger.getAccountBlocksByFilter.
+	// The first start with the indexer enabled indexes the whole chain in the background.
+	EnableIndexer bool
+
+	// MaxHistoryDepth is the number of momentums behind the frontier which can be used as momentumHeight parameter.
+	// If 0, zenon.DefaultMaxHistoryDepth is used.
+	MaxHistoryDepth uint64
+}
+
+// MetricsConfig enables a HTTP listener which serves the metrics in the Prometheus text format on /metrics.
+// Metrics collection is enabled when the listener starts, before the RPC server, even if znnd isn't started with the --metrics flag.
+type MetricsConfig struct {
+	Enabled  bool
+	HTTPHost string
+	HTTPPort int
+}
+
+// DevConfig runs a single-node chain with one local pillar & accounts prefunded with ZNN, QSR & plasma.
+// The keys are derived from genesis.DevMnemonic & the genesis is created in DataPath on the first start.
+// Networking is disabled & the producer only creates momentums when there are new account blocks.
+// The slots last 1s instead of 10s, but the durations counted in momentums (MomentumsPerEpoch, FuseExpiration, ...)
+// & the reward tables in vm/constants still assume 10s momentums, so they don't match the wall-clock time of the dev chain.
+type DevConfig struct {
+	Enabled bool
+	Period  int64 // seconds after which an empty momentum is produced anyway, if 0, momentums are only produced on demand
 }
 type NetConfig struct {
 	ListenHost string
@@ -57,12 +164,28 @@ type Config struct {
 
 	LogLevel string // "debug", "dbug" | "info" | "warn" | "error", "eror" | "crit"
 
-	Producer *ProducerConfig
-	RPC      RPCConfig
-	Net      NetConfig
+	Producer     *ProducerConfig
+	AutoReceiver *AutoReceiverConfig
+	Webhooks     []WebhookConfig
+	RPC          RPCConfig
+	Net          NetConfig
+	Metrics      MetricsConfig
+	Dev          DevConfig
+}
+
+// MetricsEndpoint returns the listening address of the metrics server, or an empty string if it's disabled
+func (c *Config) MetricsEndpoint() string {
+	if !c.Metrics.Enabled {
+		return ""
+	}
+	return fmt.Sprintf("%v:%v", c.Metrics.HTTPHost, c.Metrics.HTTPPort)
 }
 
 func (c *Config) MakePathsAbsolute() error {
+	// keep the dev chain apart from the databases of the network
+	if c.Dev.Enabled && (c.DataPath == "" || c.DataPath == DefaultDataDir()) {
+		c.DataPath = filepath.Join(DefaultDataDir(), DefaultDevDir)
+	}
 	if c.DataPath == "" {
 		c.DataPath = DefaultDataDir()
 	} else {
@@ -97,6 +220,9 @@ func (c *Config) MakePathsAbsolute() error {
 }
 
 func (c *Config) makeZenonConfig(walletManager *wallet.Manager) (*zenon.Config, error) {
+	if c.Dev.Enabled {
+		return c.makeDevZenonConfig()
+	}
 	pillarCoinbase, err := c.parseProducer(walletManager)
 	if err != nil {
 		return nil, err
@@ -107,6 +233,8 @@ func (c *Config) makeZenonConfig(walletManager *wallet.Manager) (*zenon.Config,
 		ProducingKeyPair: pillarCoinbase,
 		GenesisConfig:    c.makeGenesisConfig(),
 		DataDir:          c.DataPath,
+		MaxHistoryDepth:  c.RPC.MaxHistoryDepth,
+		EnableIndexer:    c.RPC.EnableIndexer,
 	}, nil
 }
 func (c *Config) makeGenesisConfig() (genesisConfig store.Genesis) {
@@ -182,6 +310,66 @@ func (c *Config) parseProducer(walletManager *wallet.Manager) (*wallet.KeyPair,
 	return keyPair, nil
 }
 
+func (c *Config) makeReceiverConfig() (*receiver.Config, error) {
+	if c.AutoReceiver == nil {
+		return nil, nil
+	}
+
+	config := &receiver.Config{}
+	for _, addressStr := range c.AutoReceiver.Addresses {
+		address, err := types.ParseAddress(addressStr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse auto-receiver address. Reason:%w", err)
+		}
+		config.Addresses = append(config.Addresses, address)
+	}
+	for _, ztsStr := range c.AutoReceiver.TokenStandards {
+		zts, err := types.ParseZTS(ztsStr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse auto-receiver token standard. Reason:%w", err)
+		}
+		config.TokenStandards = append(config.TokenStandards, zts)
+	}
+	if c.AutoReceiver.MinAmount != "" {
+		minAmount, ok := new(big.Int).SetString(c.AutoReceiver.MinAmount, 10)
+		if !ok || minAmount.Sign() == -1 {
+			return nil, errors.Errorf("unable to parse auto-receiver min amount %v", c.AutoReceiver.MinAmount)
+		}
+		config.MinAmount = minAmount
+	}
+	return config, nil
+}
+func (c *Config) makeWebhookConfig() (*webhook.Config, error) {
+	if len(c.Webhooks) == 0 {
+		return nil, nil
+	}
+
+	config := &webhook.Config{
+		QueuePath:      filepath.Join(c.DataPath, DefaultWebhookDir, "queue"),
+		DeadLetterPath: filepath.Join(c.DataPath, DefaultWebhookDir, "dead-letter.log"),
+	}
+	for _, hookConfig := range c.Webhooks {
+		secret, err := hex.DecodeString(strings.TrimPrefix(hookConfig.Secret, "0x"))
+		if err != nil {
+			return nil, errors.Errorf("invalid secret for webhook %v. Reason:%v", hookConfig.Name, err)
+		}
+		if len(secret) == 0 {
+			return nil, errors.Errorf("invalid secret for webhook %v. Reason:missing", hookConfig.Name)
+		}
+		config.Hooks = append(config.Hooks, &webhook.HookConfig{
+			Name:           hookConfig.Name,
+			URL:            hookConfig.URL,
+			Secret:         secret,
+			Momentums:      hookConfig.Momentums,
+			Filter:         hookConfig.Filter,
+			BatchSize:      hookConfig.BatchSize,
+			MaxAttempts:    hookConfig.MaxAttempts,
+			InitialBackoff: time.Duration(hookConfig.InitialBackoff) * time.Millisecond,
+			MaxBackoff:     time.Duration(hookConfig.MaxBackoff) * time.Millisecond,
+		})
+	}
+	return config, nil
+}
 func (c *Config) makeWalletConfig() *wallet.Config {
 	return &wallet.Config{WalletDir: c.WalletPath}
 }
@@ -206,6 +394,24 @@ func (c *Config) HTTPEndpoint() string {
 	}
 	return fmt.Sprintf("%s:%d", c.RPC.HTTPHost, c.RPC.HTTPPort)
 }
+
+// IPCEndpoint resolves IPCPath into the endpoint used by the IPC server
+func (c *Config) IPCEndpoint() string {
+	if c.RPC.IPCPath == "" {
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		if strings.HasPrefix(c.RPC.IPCPath, `\\.\pipe\`) {
+			return c.RPC.IPCPath
+		}
+		return `\\.\pipe\` + c.RPC.IPCPath
+	}
+	path := ReplaceHomeVariable(c.RPC.IPCPath)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.DataPath, path)
+}
 func (c *Config) WSEndpoint() string {
 	if c.RPC.WSHost == "" {
 		return ""
@@ -10,9 +10,47 @@ import (
 )
 
 const (
-	DefaultWalletDir = "wallet"
+	DefaultWalletDir  = "wallet"
+	DefaultIPCPath    = "znnd.ipc"
+	DefaultDevDir     = "dev"
+	DefaultWebhookDir = "webhooks"
+
+	DefaultMaxMomentumAgeSec = 120
+
+	DefaultMetricsHost = "127.0.0.1"
+	DefaultMetricsPort = 35999
 )
 
+// DefaultRPCWeights are the costs of the expensive calls, used when rate limiting is enabled
+var DefaultRPCWeights = map[string]float64{
+	"ledger.getDetailedMomentumsByHeight": 10,
+	"ledger.getMomentumsByHeight":         5,
+	"ledger.getMomentumsByPage":           5,
+	"ledger.getAccountBlocksByHeight":     5,
+	"ledger.getAccountBlocksByPage":       5,
+	"ledger.getAccountBlocksByFilter":     10,
+	"ledger.simulateBlock":                5,
+	"ledger.generatePoW":                  50,
+	"embedded.pillar.getAll":              10,
+	"embedded.sentinel.getAllActive":      10,
+	"embedded.spork.getAll":               5,
+	"embedded.token.getAll":               10,
+
+	// accept a momentumHeight, for which the state is rebuilt by rolling back every momentum above it
+	"ledger.getAccountInfoByAddress":         2,
+	"embedded.pillar.getDepositedQsr":        2,
+	"embedded.pillar.getUncollectedReward":   2,
+	"embedded.pillar.getQsrRegistrationCost": 2,
+	"embedded.pillar.checkNameAvailability":  2,
+	"embedded.pillar.getDelegatedPillar":     2,
+	"embedded.plasma.get":                    2,
+	"embedded.plasma.getEntriesByAddress":    5,
+	"embedded.stake.getUncollectedReward":    2,
+	"embedded.stake.getEntriesByAddress":     5,
+	"embedded.token.getByOwner":              10,
+	"embedded.token.getByZts":                2,
+}
+
 var DefaultNodeConfig = Config{
 	DataPath: DefaultDataDir(),
 
@@ -30,6 +68,12 @@ var DefaultNodeConfig = Config{
 
 		HTTPCors:  []string{"*"},
 		WSOrigins: []string{"*"},
+
+		IPCPath: DefaultIPCPath,
+	},
+	Metrics: MetricsConfig{
+		HTTPHost: DefaultMetricsHost,
+		HTTPPort: DefaultMetricsPort,
 	},
 	Net: NetConfig{
 		ListenHost:      p2p.DefaultListenHost,
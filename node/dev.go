@@ -0,0 +1,84 @@
+package node
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/zenon-network/go-zenon/chain/genesis"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+const (
+	devGenesisFile = "genesis-dev.json"
+	// devBlockTime is the slot length of the dev chain, the producer skips the slots without new account blocks
+	devBlockTime = 1
+)
+
+// devConsensusConfig is constants.ConsensusConfig with the slots of the dev chain
+func devConsensusConfig() *constants.Consensus {
+	config := *constants.ConsensusConfig
+	config.BlockTime = devBlockTime
+	return &config
+}
+
+// makeDevGenesis reads the genesis of the dev chain from DataPath, it's created on the first start
+func (c *Config) makeDevGenesis() (store.Genesis, error) {
+	path := filepath.Join(c.DataPath, devGenesisFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		config, err := genesis.NewDevGenesisConfig(common.Clock.Now().Unix())
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(config, "", "    ")
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(path, data, 0600); err != nil {
+			return nil, err
+		}
+		log.Info("created dev genesis", "path", path)
+	}
+	return genesis.ReadGenesisConfigFromFile(path)
+}
+func (c *Config) makeDevZenonConfig() (*zenon.Config, error) {
+	genesisConfig, err := c.makeDevGenesis()
+	if err != nil {
+		return nil, err
+	}
+	keyPairs, err := genesis.DevKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+	return &zenon.Config{
+		MinPeers:         0,
+		ProducingKeyPair: keyPairs[0],
+		GenesisConfig:    genesisConfig,
+		Consensus:        devConsensusConfig(),
+		DataDir:          c.DataPath,
+		MaxHistoryDepth:  c.RPC.MaxHistoryDepth,
+		EnableIndexer:    c.RPC.EnableIndexer,
+	}, nil
+}
+
+func printDevKeys() {
+	keyPairs, err := genesis.DevKeyPairs()
+	if err != nil {
+		return
+	}
+	fmt.Printf("Running a dev chain with chain identifier %v. Never use the following keys outside of it!\n", genesis.DevChainIdentifier)
+	fmt.Printf("Mnemonic: %v\n", genesis.DevMnemonic)
+	for i, keyPair := range keyPairs {
+		fmt.Printf("(%v) %v private key: %v", i, keyPair.Address, hex.EncodeToString(keyPair.Private.Seed()))
+		if i == 0 {
+			fmt.Printf(" (pillar %v)", genesis.DevPillarName)
+		}
+		fmt.Printf("\n")
+	}
+}
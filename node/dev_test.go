@@ -0,0 +1,117 @@
+package node
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/chain/genesis"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/offline"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/constants"
+)
+
+// the mock zenon of the other tests replaces common.Clock
+type devTestClock struct{}
+
+func (devTestClock) Now() time.Time {
+	return time.Now()
+}
+
+func startDevNode(t *testing.T, dataPath string, period int64) *Node {
+	config := DefaultNodeConfig
+	config.DataPath = dataPath
+	config.RPC = RPCConfig{}
+	config.Metrics = MetricsConfig{}
+	config.Dev = DevConfig{Enabled: true, Period: period}
+	common.FailIfErr(t, config.MakePathsAbsolute())
+	node, err := NewNode(&config)
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, node.Start())
+	return node
+}
+
+func devFrontierHeight(t *testing.T, node *Node) uint64 {
+	momentum, err := frontierMomentum(node.Zenon())
+	common.FailIfErr(t, err)
+	return momentum.Height
+}
+
+func waitForDevHeight(t *testing.T, node *Node, height uint64) {
+	for i := 0; i < 50 && devFrontierHeight(t, node) < height; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	common.Expect(t, devFrontierHeight(t, node), height)
+}
+
+// - test that the dev genesis funds the dev keys & is reused after a restart
+// - test that momentums are produced as soon as there are new account blocks
+// - test that empty momentums are only produced once the period is over
+// - test that the slots of the dev chain don't change the consensus config of the network
+func TestDevMode(t *testing.T) {
+	defaultClock := common.Clock
+	common.Clock = devTestClock{}
+	defer func() { common.Clock = defaultClock }()
+	dataPath, err := ioutil.TempDir("", "znnd-dev-test")
+	common.FailIfErr(t, err)
+	defer os.RemoveAll(dataPath)
+
+	node := startDevNode(t, dataPath, 3)
+	z := node.Zenon()
+	common.Expect(t, constants.ConsensusConfig.BlockTime, int64(10))
+	keyPairs, err := genesis.DevKeyPairs()
+	common.FailIfErr(t, err)
+	sender, receiver := keyPairs[1], keyPairs[2]
+	common.Expect(t, z.Chain().ChainIdentifier(), uint64(genesis.DevChainIdentifier))
+	common.ExpectString(t, z.Producer().GetCoinBase().String(), keyPairs[0].Address.String())
+
+	momentum, err := frontierMomentum(z)
+	common.FailIfErr(t, err)
+	common.Expect(t, momentum.Height, uint64(1))
+	balance, err := z.Chain().GetMomentumStore(momentum.Identifier()).GetAccountStore(sender.Address).GetBalance(types.ZnnTokenStandard)
+	common.FailIfErr(t, err)
+	common.ExpectString(t, balance.String(), "100000000000000")
+
+	frontier, err := z.Chain().GetFrontierAccountStore(sender.Address).Frontier()
+	common.FailIfErr(t, err)
+	info, err := offline.NewChainInfo(sender.Address, frontier, momentum)
+	common.FailIfErr(t, err)
+	block, err := offline.Build(context.Background(), info, &nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserSend,
+		Address:       sender.Address,
+		ToAddress:     receiver.Address,
+		Amount:        big.NewInt(100000000),
+		TokenStandard: types.ZnnTokenStandard,
+	})
+	common.FailIfErr(t, err)
+	block.Hash = block.ComputeHash()
+	block.Signature, _, block.PublicKey, err = sender.Signer(block.Hash.Bytes())
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, api.NewLedgerApi(z).PublishRawTransaction(&api.AccountBlock{AccountBlock: *block}))
+
+	waitForDevHeight(t, node, 2)
+	withBlock, err := frontierMomentum(z)
+	common.FailIfErr(t, err)
+	common.Expect(t, len(withBlock.Content), 1)
+	common.ExpectString(t, withBlock.Content[0].Hash.String(), block.Hash.String())
+	common.ExpectTrue(t, withBlock.Timestamp.Sub(*momentum.Timestamp) < 3*time.Second)
+
+	waitForDevHeight(t, node, 3)
+	empty, err := frontierMomentum(z)
+	common.FailIfErr(t, err)
+	common.Expect(t, len(empty.Content), 0)
+	common.ExpectTrue(t, empty.Timestamp.Sub(*withBlock.Timestamp) >= 3*time.Second)
+
+	genesisHash := z.Chain().GetGenesisMomentum().Hash
+	common.FailIfErr(t, node.Stop())
+	// the databases of a stopped node can't be reopened in the same process
+	reloaded, err := node.Config().makeDevGenesis()
+	common.FailIfErr(t, err)
+	common.ExpectString(t, reloaded.GetGenesisMomentum().Hash.String(), genesisHash.String())
+}
@@ -0,0 +1,120 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/protocol"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+var syncStateNames = map[protocol.SyncState]string{
+	protocol.Unknown:        "unknown",
+	protocol.Syncing:        "syncing",
+	protocol.SyncDone:       "done",
+	protocol.NotEnoughPeers: "not-enough-peers",
+}
+
+type healthCheck struct {
+	Ok     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+type healthResponse struct {
+	Ok     bool                    `json:"ok"`
+	Checks map[string]*healthCheck `json:"checks"`
+}
+
+// healthHandler serves /health, which checks that the process is alive & the database is open,
+// and /ready, which checks that the node is synced, has enough peers & a recent frontier momentum.
+// Both answer with 200 if all checks pass and 503 otherwise.
+type healthHandler struct {
+	z              zenon.Zenon
+	peers          peerCounter
+	minPeers       int
+	maxMomentumAge time.Duration
+}
+
+func newHealthHandler(z zenon.Zenon, peers peerCounter, minPeers int, maxMomentumAge time.Duration) *healthHandler {
+	return &healthHandler{
+		z:              z,
+		peers:          peers,
+		minPeers:       minPeers,
+		maxMomentumAge: maxMomentumAge,
+	}
+}
+
+// frontierMomentum returns an error instead of panicking if the database is closed
+func frontierMomentum(z zenon.Zenon) (*nom.Momentum, error) {
+	store := z.Chain().GetFrontierMomentumStore()
+	if store == nil {
+		return nil, errors.New("database is closed")
+	}
+	return store.GetFrontierMomentum()
+}
+
+func (h *healthHandler) checkDatabase() *healthCheck {
+	momentum, err := frontierMomentum(h.z)
+	if err != nil {
+		return &healthCheck{Ok: false, Detail: err.Error()}
+	}
+	return &healthCheck{Ok: true, Detail: fmt.Sprintf("frontier momentum height %v", momentum.Height)}
+}
+func (h *healthHandler) checkSync() *healthCheck {
+	info := h.z.Broadcaster().SyncInfo()
+	return &healthCheck{
+		Ok:     info.State == protocol.SyncDone,
+		Detail: fmt.Sprintf("state %v, height %v of %v", syncStateNames[info.State], info.CurrentHeight, info.TargetHeight),
+	}
+}
+func (h *healthHandler) checkPeers() *healthCheck {
+	count := h.peers.PeerCount()
+	return &healthCheck{
+		Ok:     count >= h.minPeers,
+		Detail: fmt.Sprintf("%v peers, expected at least %v", count, h.minPeers),
+	}
+}
+func (h *healthHandler) checkMomentumAge() *healthCheck {
+	momentum, err := frontierMomentum(h.z)
+	if err != nil {
+		return &healthCheck{Ok: false, Detail: err.Error()}
+	}
+	age := common.Clock.Now().Sub(time.Unix(int64(momentum.TimestampUnix), 0)).Truncate(time.Second)
+	return &healthCheck{
+		Ok:     age <= h.maxMomentumAge,
+		Detail: fmt.Sprintf("frontier momentum height %v is %v old, expected at most %v", momentum.Height, age, h.maxMomentumAge),
+	}
+}
+
+func (h *healthHandler) serveHealth(w http.ResponseWriter, r *http.Request) {
+	writeHealthResponse(w, map[string]*healthCheck{
+		"database": h.checkDatabase(),
+	})
+}
+func (h *healthHandler) serveReady(w http.ResponseWriter, r *http.Request) {
+	writeHealthResponse(w, map[string]*healthCheck{
+		"sync":     h.checkSync(),
+		"peers":    h.checkPeers(),
+		"momentum": h.checkMomentumAge(),
+	})
+}
+
+func writeHealthResponse(w http.ResponseWriter, checks map[string]*healthCheck) {
+	response := &healthResponse{Ok: true, Checks: checks}
+	for _, check := range checks {
+		response.Ok = response.Ok && check.Ok
+	}
+	w.Header().Set("content-type", "application/json")
+	if response.Ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
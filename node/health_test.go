@@ -0,0 +1,77 @@
+package node
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/zenon"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+type healthTestClock time.Time
+
+func (c healthTestClock) Now() time.Time {
+	return time.Time(c)
+}
+
+type healthTestZenon struct {
+	zenon.Zenon
+	chain chain.Chain
+}
+
+func (z *healthTestZenon) Chain() chain.Chain {
+	return z.chain
+}
+
+func getHealth(t *testing.T, handler http.HandlerFunc, path string) (int, string) {
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	body, err := ioutil.ReadAll(recorder.Body)
+	common.FailIfErr(t, err)
+	common.ExpectString(t, recorder.Header().Get("content-type"), "application/json")
+	return recorder.Code, string(body)
+}
+
+// - test /health while the database is open & after it's closed
+// - test the peers & momentum age thresholds of /ready
+func TestHealthHandler(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	health := newHealthHandler(z, metricsTestPeers(2), 3, 30*time.Second)
+
+	code, body := getHealth(t, health.serveHealth, "/health")
+	common.Expect(t, code, http.StatusOK)
+	common.ExpectString(t, body, `{"ok":true,"checks":{"database":{"ok":true,"detail":"frontier momentum height 1"}}}
+`)
+	code, body = getHealth(t, health.serveReady, "/ready")
+	common.Expect(t, code, http.StatusServiceUnavailable)
+	common.ExpectString(t, body, `{"ok":false,"checks":{"momentum":{"ok":true,"detail":"frontier momentum height 1 is 0s old, expected at most 30s"},"peers":{"ok":false,"detail":"2 peers, expected at least 3"},"sync":{"ok":true,"detail":"state done, height 0 of 0"}}}
+`)
+
+	health.minPeers = 2
+	code, body = getHealth(t, health.serveReady, "/ready")
+	common.Expect(t, code, http.StatusOK)
+	common.ExpectString(t, body, `{"ok":true,"checks":{"momentum":{"ok":true,"detail":"frontier momentum height 1 is 0s old, expected at most 30s"},"peers":{"ok":true,"detail":"2 peers, expected at least 2"},"sync":{"ok":true,"detail":"state done, height 0 of 0"}}}
+`)
+
+	z.InsertMomentumsTo(10)
+	mockClock := common.Clock
+	common.Clock = healthTestClock(mockClock.Now().Add(time.Minute))
+	code, body = getHealth(t, health.serveReady, "/ready")
+	common.Clock = mockClock
+	common.Expect(t, code, http.StatusServiceUnavailable)
+	common.ExpectString(t, body, `{"ok":false,"checks":{"momentum":{"ok":false,"detail":"frontier momentum height 10 is 1m0s old, expected at most 30s"},"peers":{"ok":true,"detail":"2 peers, expected at least 2"},"sync":{"ok":true,"detail":"state done, height 0 of 0"}}}
+`)
+
+	// the mock discards its chain when stopped, keep using the closed one
+	health.z = &healthTestZenon{Zenon: z, chain: z.Chain()}
+	z.StopPanic()
+	code, body = getHealth(t, health.serveHealth, "/health")
+	common.Expect(t, code, http.StatusServiceUnavailable)
+	common.ExpectString(t, body, `{"ok":false,"checks":{"database":{"ok":false,"detail":"database is closed"}}}
+`)
+}
@@ -0,0 +1,53 @@
+//go:build !windows && !js
+// +build !windows,!js
+
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zenon-network/go-zenon/common"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+)
+
+type ipcTestApi struct{}
+
+func (ipcTestApi) Echo(message string) string {
+	return message
+}
+
+// - test that IPCPath is resolved relative to DataPath
+// - test that the IPC server exposes non-public APIs on a socket which only the owner can access
+// - test that stopping the IPC server removes the socket
+func TestIPCServer(t *testing.T) {
+	dataPath := t.TempDir()
+	config := &Config{DataPath: dataPath, RPC: RPCConfig{IPCPath: DefaultIPCPath}}
+	endpoint := config.IPCEndpoint()
+	common.ExpectString(t, endpoint, filepath.Join(dataPath, DefaultIPCPath))
+	config.RPC.IPCPath = ""
+	common.ExpectString(t, config.IPCEndpoint(), "")
+
+	server := newIPCServer(endpoint)
+	common.FailIfErr(t, server.start([]rpc.API{{
+		Namespace: "test",
+		Service:   ipcTestApi{},
+		Public:    false,
+	}}))
+	info, err := os.Stat(endpoint)
+	common.FailIfErr(t, err)
+	common.Expect(t, info.Mode().Perm(), os.FileMode(0600))
+
+	client, err := rpc.DialIPC(context.Background(), endpoint)
+	common.FailIfErr(t, err)
+	var result string
+	common.FailIfErr(t, client.Call(&result, "test.echo", "private"))
+	common.ExpectString(t, result, "private")
+	client.Close()
+
+	common.FailIfErr(t, server.stop())
+	_, err = os.Stat(endpoint)
+	common.ExpectTrue(t, os.IsNotExist(err))
+}
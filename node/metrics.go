@@ -0,0 +1,110 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+type peerCounter interface {
+	PeerCount() int
+}
+
+// metricsServer serves all registered metrics in the Prometheus text format on /metrics
+type metricsServer struct {
+	log      common.Logger
+	endpoint string
+
+	mu       sync.Mutex
+	listener net.Listener
+	server   *http.Server
+	gauges   []string // names of the gauges registered by start
+}
+
+// newMetricsServer returns a server which is disabled if endpoint is empty
+func newMetricsServer(endpoint string) *metricsServer {
+	return &metricsServer{
+		log:      common.NodeLogger.New("submodule", "metrics"),
+		endpoint: endpoint,
+	}
+}
+
+// registerGauges adds the gauges which are computed from z & peers on every scrape
+func (ms *metricsServer) registerGauges(z zenon.Zenon, peers peerCounter) {
+	gauges := map[string]func() int64{
+		"chain/height": func() int64 {
+			momentum, err := frontierMomentum(z)
+			if err != nil {
+				return 0
+			}
+			return int64(momentum.Height)
+		},
+		"chain/accountpool/blocks": func() int64 {
+			return int64(len(z.Chain().GetAllUncommittedAccountBlocks()))
+		},
+		"sync/state": func() int64 {
+			return int64(z.Broadcaster().SyncInfo().State)
+		},
+		"sync/target": func() int64 {
+			return int64(z.Broadcaster().SyncInfo().TargetHeight)
+		},
+		"p2p/peers": func() int64 {
+			return int64(peers.PeerCount())
+		},
+	}
+	for name, f := range gauges {
+		metrics.NewRegisteredFunctionalGauge(name, nil, f)
+		ms.gauges = append(ms.gauges, name)
+	}
+}
+
+func (ms *metricsServer) start(z zenon.Zenon, peers peerCounter) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.endpoint == "" || ms.listener != nil {
+		return nil // already running or not configured
+	}
+	// the gauges are only collected if go-ethereum/metrics is enabled,
+	// which is done on init by the --metrics flag, but not by the config file;
+	// start is called before the RPC server, whose gauges & timers are registered on the first call
+	if !metrics.Enabled {
+		ms.log.Info("enabling metrics collection")
+		metrics.Enabled = true
+	}
+	listener, err := net.Listen("tcp", ms.endpoint)
+	if err != nil {
+		return err
+	}
+	ms.registerGauges(z, peers)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", prometheus.Handler(metrics.DefaultRegistry))
+	ms.listener = listener
+	ms.server = &http.Server{Handler: mux, ReadTimeout: 10 * time.Second, WriteTimeout: 30 * time.Second}
+	go ms.server.Serve(listener)
+	ms.log.Info("metrics server started", "url", fmt.Sprintf("http://%v/metrics", listener.Addr()))
+	return nil
+}
+
+func (ms *metricsServer) stop() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.listener == nil {
+		return // not running
+	}
+	ms.server.Close()
+	for _, name := range ms.gauges {
+		metrics.DefaultRegistry.Unregister(name)
+	}
+	ms.listener, ms.server, ms.gauges = nil, nil, nil
+	ms.log.Info("metrics server stopped")
+}
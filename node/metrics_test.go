@@ -0,0 +1,57 @@
+package node
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+type metricsTestPeers int
+
+func (p metricsTestPeers) PeerCount() int {
+	return int(p)
+}
+
+// - test that the metrics server enables metrics collection if the node wasn't started with --metrics
+// - test that the counters of other modules are served, even though collection was disabled when they were registered
+// - test that the node gauges are served in the Prometheus text format
+// - test that stopping the server unregisters the node gauges
+func TestMetricsServer(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	config := &Config{Metrics: MetricsConfig{HTTPHost: "127.0.0.1", HTTPPort: 0}}
+	common.ExpectString(t, config.MetricsEndpoint(), "")
+	config.Metrics.Enabled = true
+	common.ExpectString(t, config.MetricsEndpoint(), "127.0.0.1:0")
+
+	enabled := metrics.Enabled
+	defer func() { metrics.Enabled = enabled }()
+	metrics.Enabled = false
+	server := newMetricsServer(config.MetricsEndpoint())
+	common.FailIfErr(t, server.start(z, metricsTestPeers(3)))
+	common.ExpectTrue(t, metrics.Enabled)
+	response, err := http.Get(fmt.Sprintf("http://%v/metrics", server.listener.Addr()))
+	common.FailIfErr(t, err)
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	common.FailIfErr(t, err)
+	for _, line := range []string{
+		"# TYPE chain_height gauge\nchain_height 1\n",
+		"# TYPE chain_accountpool_blocks gauge\nchain_accountpool_blocks 0\n",
+		"# TYPE p2p_peers gauge\np2p_peers 3\n",
+		"# TYPE chain_momentums_inserted gauge\n",
+		"# TYPE db_cache_misses gauge\n",
+	} {
+		common.ExpectTrue(t, strings.Contains(string(body), line))
+	}
+
+	server.stop()
+	common.ExpectTrue(t, metrics.DefaultRegistry.Get("chain/height") == nil)
+}
@@ -5,15 +5,18 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/tsdb/fileutil"
 
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/receiver"
 	api "github.com/zenon-network/go-zenon/rpc"
 	rpc "github.com/zenon-network/go-zenon/rpc/server"
 	"github.com/zenon-network/go-zenon/wallet"
+	"github.com/zenon-network/go-zenon/webhook"
 	"github.com/zenon-network/go-zenon/zenon"
 )
 
@@ -27,15 +30,22 @@ type Node struct {
 
 	walletManager *wallet.Manager
 	server        *p2p.Server
+	receiver      receiver.Receiver // nil if the auto-receiver is disabled
+	webhooks      webhook.Sink      // nil if no webhooks are configured
 
 	z zenon.Zenon
 
 	rpcAPIs []rpc.API   // List of APIs currently provided by the node
 	http    *httpServer //
 	ws      *httpServer //
+	ipc     *ipcServer  // serves all APIs, including the non-public ones
+
+	metrics *metricsServer
 
 	// Channel to wait for termination notifications
 	stop        chan struct{}
+	shutdown    chan struct{} // closed once a shutdown is requested over RPC
+	shutdownReq sync.Once
 	lock        sync.RWMutex
 	dataDirLock fileutil.Releaser // prevents concurrent use of instance directory
 }
@@ -46,9 +56,12 @@ func NewNode(conf *Config) (*Node, error) {
 	node := &Node{
 		config:        conf,
 		stop:          make(chan struct{}),
+		shutdown:      make(chan struct{}),
 		walletManager: wallet.New(conf.makeWalletConfig()),
 		http:          newHTTPServer(rpc.DefaultHTTPTimeouts),
 		ws:            newHTTPServer(rpc.DefaultHTTPTimeouts),
+		ipc:           newIPCServer(conf.IPCEndpoint()),
+		metrics:       newMetricsServer(conf.MetricsEndpoint()),
 	}
 
 	// prepare node
@@ -73,6 +86,29 @@ func NewNode(conf *Config) (*Node, error) {
 		log.Error("failed to create zenon", "reason", err)
 		return nil, err
 	}
+	if conf.Dev.Enabled {
+		node.z.Producer().ProduceOnDemand(time.Duration(conf.Dev.Period) * time.Second)
+		printDevKeys()
+	}
+
+	receiverConfig, err := node.config.makeReceiverConfig()
+	if err != nil {
+		return nil, err
+	}
+	if receiverConfig != nil {
+		node.receiver = receiver.NewReceiver(receiverConfig, node.z, node.walletManager)
+	}
+	webhookConfig, err := node.config.makeWebhookConfig()
+	if err != nil {
+		return nil, err
+	}
+	if webhookConfig != nil {
+		node.webhooks, err = webhook.NewSink(webhookConfig, node.z.Chain())
+		if err != nil {
+			log.Error("failed to create webhooks", "reason", err)
+			return nil, err
+		}
+	}
 
 	netConfig := conf.makeNetConfig()
 	nodes, err := netConfig.Nodes()
@@ -94,6 +130,14 @@ func NewNode(conf *Config) (*Node, error) {
 		ListenAddr:      fmt.Sprintf("%v:%v", netConfig.ListenAddr, netConfig.ListenPort),
 		Protocols:       node.z.Protocol().SubProtocols,
 	}
+	if conf.Dev.Enabled {
+		// the dev chain has no peers, neither dial nor listen
+		node.server.MaxPeers = 0
+		node.server.Discovery = false
+		node.server.NoDial = true
+		node.server.ListenAddr = ""
+		node.server.StaticNodes, node.server.BootstrapNodes, node.server.TrustedNodes = nil, nil, nil
+	}
 	return node, nil
 }
 
@@ -107,7 +151,23 @@ func (node *Node) Start() error {
 	if err := node.server.Start(); err != nil {
 		return err
 	}
+	if node.receiver != nil {
+		if err := node.receiver.Start(); err != nil {
+			log.Error("failed to start auto-receiver", "reason", err)
+			return err
+		}
+	}
 	node.rpcAPIs = api.GetPublicApis(node.z, node.server)
+	if node.config.RPC.EnablePoW {
+		node.rpcAPIs = append(node.rpcAPIs, api.GetApis(node.z, node.server, "pow")...)
+	}
+	node.rpcAPIs = append(node.rpcAPIs, api.GetWalletApis(node.z, node.walletManager)...)
+	node.rpcAPIs = append(node.rpcAPIs, api.GetAdminApis(node.z, node.server, node.config.DataPath, node.RequestShutdown)...)
+	// metrics collection is enabled before the RPC timers are created by the first calls
+	if err := node.metrics.start(node.z, node.server); err != nil {
+		log.Error("failed to start metrics server", "reason", err)
+		return err
+	}
 	if err := node.startRPC(); err != nil {
 		log.Error("failed to start rpc", "reason", err)
 		return err
@@ -123,6 +183,18 @@ func (node *Node) Stop() error {
 	log.Info("stopping p2p server ...")
 	node.server.Stop()
 
+	if node.receiver != nil {
+		if err := node.receiver.Stop(); err != nil {
+			log.Error("failed to stop auto-receiver", "reason", err)
+			return err
+		}
+	}
+	if node.webhooks != nil {
+		if err := node.webhooks.Stop(); err != nil {
+			log.Error("failed to stop webhooks", "reason", err)
+			return err
+		}
+	}
 	if err := node.stopWallet(); err != nil {
 		log.Error("failed to stop wallet", "reason", err)
 		return err
@@ -132,6 +204,7 @@ func (node *Node) Stop() error {
 		return err
 	}
 	node.stopRPC()
+	node.metrics.stop()
 
 	// Release instance directory lock.
 	node.closeDataDir()
@@ -142,6 +215,16 @@ func (node *Node) Wait() {
 	<-node.stop
 }
 
+// RequestShutdown asks the owner of the node to stop it, it doesn't block
+func (node *Node) RequestShutdown() {
+	node.shutdownReq.Do(func() {
+		close(node.shutdown)
+	})
+}
+func (node *Node) ShutdownRequested() <-chan struct{} {
+	return node.shutdown
+}
+
 func (node *Node) Zenon() zenon.Zenon {
 	return node.z
 }
@@ -163,6 +246,13 @@ func (node *Node) startZenon() error {
 		log.Error("failed to init zenon", "reason", err)
 		return err
 	}
+	// registered before zenon & the p2p server start, so no inserted momentum is missed
+	if node.webhooks != nil {
+		if err := node.webhooks.Start(); err != nil {
+			log.Error("failed to start webhooks", "reason", err)
+			return err
+		}
+	}
 	if err := node.z.Start(); err != nil {
 		log.Error("failed to start zenon", "reason", err)
 		return err
@@ -0,0 +1,99 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/tsdb/fileutil"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+// OfflineChain gives commands access to the chain of DataPath while the node is not running
+type OfflineChain struct {
+	chain.Chain
+	genesis     store.Genesis
+	config      *constants.Consensus
+	consensus   consensus.Consensus
+	dataDirLock fileutil.Releaser
+}
+
+func OpenOfflineChain(c *Config) (*OfflineChain, error) {
+	if err := os.MkdirAll(c.DataPath, 0700); err != nil {
+		return nil, err
+	}
+	fileLock, _, err := fileutil.Flock(filepath.Join(c.DataPath, ".lock"))
+	if err != nil {
+		return nil, convertFileLockError(err)
+	}
+
+	var genesisConfig store.Genesis
+	var consensusConfig *constants.Consensus
+	if c.Dev.Enabled {
+		consensusConfig = devConsensusConfig()
+		if genesisConfig, err = c.makeDevGenesis(); err != nil {
+			_ = fileLock.Release()
+			return nil, err
+		}
+	} else {
+		genesisConfig = c.makeGenesisConfig()
+	}
+
+	zenonConfig := &zenon.Config{DataDir: c.DataPath}
+	offline, err := newOfflineChain(genesisConfig, consensusConfig, zenonConfig.NewDBManager("nom"), zenonConfig.NewLevelDB("consensus"))
+	if err != nil {
+		_ = fileLock.Release()
+		return nil, err
+	}
+	offline.dataDirLock = fileLock
+	return offline, nil
+}
+
+func newOfflineChain(genesisConfig store.Genesis, consensusConfig *constants.Consensus, chainManager db.Manager, consensusDB db.DB) (*OfflineChain, error) {
+	offline := &OfflineChain{
+		Chain:   chain.NewChain(chainManager, genesisConfig),
+		genesis: genesisConfig,
+		config:  consensusConfig,
+	}
+	if err := offline.Init(); err != nil {
+		_ = offline.Chain.Stop()
+		return nil, err
+	}
+	// the consensus doesn't produce events while testing is set, but still follows the inserted momentums
+	offline.consensus = consensus.NewConsensus(consensusDB, offline.Chain, consensusConfig, true)
+	if err := offline.consensus.Init(); err != nil {
+		_ = offline.Close()
+		return nil, err
+	}
+	if err := offline.consensus.Start(); err != nil {
+		_ = offline.Close()
+		return nil, err
+	}
+	return offline, nil
+}
+
+// NewScratchChain creates a chain with only the genesis of c, stored in dir, used to replay the momentums of c
+func (c *OfflineChain) NewScratchChain(dir string) (*OfflineChain, error) {
+	return newOfflineChain(c.genesis, c.config, db.NewLevelDBManager(dir), db.NewMemDB())
+}
+
+// Supervisor verifies & applies momentums and account-blocks like the node does for the ones received from peers
+func (c *OfflineChain) Supervisor() *vm.Supervisor {
+	return vm.NewSupervisor(c.Chain, c.consensus)
+}
+
+func (c *OfflineChain) Close() error {
+	if c.dataDirLock != nil {
+		defer c.dataDirLock.Release()
+	}
+	if err := c.consensus.Stop(); err != nil {
+		return err
+	}
+	return c.Chain.Stop()
+}
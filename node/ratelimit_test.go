@@ -0,0 +1,129 @@
+package node
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/common"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+)
+
+type rateLimitTestApi struct{}
+
+func (rateLimitTestApi) Echo() string {
+	return "echo"
+}
+func (rateLimitTestApi) Heavy() string {
+	return "heavy"
+}
+func (rateLimitTestApi) Huge() string {
+	return "huge"
+}
+
+func newRateLimitTestServer(t *testing.T) *httptest.Server {
+	apis := []rpc.API{{Namespace: "test", Service: rateLimitTestApi{}, Public: true}}
+	auth, err := newRPCAuth(&RPCAuthConfig{
+		Tokens: []RPCTokenConfig{{Name: "admin", Secret: authTestSecret, Allow: []string{"*"}}},
+	}, apis)
+	common.FailIfErr(t, err)
+	// almost no refill during the test, so every bucket only has its burst
+	limiter := newRPCRateLimiter(&RPCRateLimitConfig{
+		Rate:       0.001,
+		Burst:      2.5,
+		TokenBurst: 11,
+		Weights:    map[string]float64{"test.heavy": 2, "test.huge": 20},
+	})
+
+	srv := rpc.NewServer()
+	srv.SetRateLimiter(limiter)
+	common.FailIfErr(t, RegisterApisFromWhitelist(apis, nil, srv, false, true))
+	ws := auth.wrap(srv.WebsocketHandler([]string{"*"}))
+	rpcHttp := auth.wrap(srv)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			ws.ServeHTTP(w, r)
+		} else {
+			rpcHttp.ServeHTTP(w, r)
+		}
+	}))
+}
+
+// - test that calls take their weight from the bucket of the remote IP
+// - test that HTTP requests are rejected with 429 once the bucket is empty
+// - test that clients with a token have their own bucket
+// - test the counters reported by the stats API
+// - test that weights above the burst take the whole bucket
+func TestRPCRateLimit_HTTP(t *testing.T) {
+	server := newRateLimitTestServer(t)
+	defer server.Close()
+	before := rpc.GetRateLimitStats()
+	call := func(token, method string) error {
+		client, err := rpc.DialHTTP(server.URL)
+		common.FailIfErr(t, err)
+		defer client.Close()
+		if token != "" {
+			client.SetHeader("Authorization", "Bearer "+token)
+		}
+		var result string
+		return client.Call(&result, method)
+	}
+
+	common.FailIfErr(t, call("", "test.echo"))
+	expectRPCError(t, call("", "test.heavy"), -32005, "rate limit exceeded for method test.heavy")
+	common.FailIfErr(t, call("", "test.echo"))
+	err := call("", "test.echo")
+	httpErr, ok := err.(rpc.HTTPError)
+	common.ExpectTrue(t, ok)
+	common.Expect(t, httpErr.StatusCode, http.StatusTooManyRequests)
+
+	token := makeTestJwt(authTestSecret, map[string]int64{"iat": time.Now().Unix()})
+	for i := 0; i < 5; i++ {
+		common.FailIfErr(t, call(token, "test.heavy"))
+	}
+	expectRPCError(t, call(token, "test.heavy"), -32005, "rate limit exceeded for method test.heavy")
+
+	// test.huge takes the whole bucket of a new server
+	other := newRateLimitTestServer(t)
+	defer other.Close()
+	client, err := rpc.DialHTTP(other.URL)
+	common.FailIfErr(t, err)
+	defer client.Close()
+	var result string
+	common.FailIfErr(t, client.Call(&result, "test.huge"))
+	common.ExpectString(t, result, "huge")
+
+	after := rpc.GetRateLimitStats()
+	common.ExpectTrue(t, after.Enabled)
+	common.Expect(t, after.AllowedCalls-before.AllowedCalls, uint64(8))
+	common.Expect(t, after.LimitedCalls-before.LimitedCalls, uint64(3))
+	common.Expect(t, after.LimitedMethods["test.heavy"]-before.LimitedMethods["test.heavy"], uint64(2))
+}
+
+// - test that the calls on a WS connection share the bucket of the remote IP & are rejected with JSON-RPC errors
+// - test that the stats only count registered methods by name
+func TestRPCRateLimit_WS(t *testing.T) {
+	server := newRateLimitTestServer(t)
+	defer server.Close()
+	before := rpc.GetRateLimitStats()
+
+	client, err := rpc.DialWebsocket(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), "")
+	common.FailIfErr(t, err)
+	defer client.Close()
+	var result string
+	common.FailIfErr(t, client.Call(&result, "test.heavy"))
+	common.ExpectString(t, result, "heavy")
+	expectRPCError(t, client.Call(&result, "test.heavy"), -32005, "rate limit exceeded for method test.heavy")
+	expectRPCError(t, client.Call(&result, "test.echo"), -32005, "rate limit exceeded for method test.echo")
+	expectRPCError(t, client.Call(&result, "test.huge"), -32005, "rate limit exceeded for method test.huge")
+	expectRPCError(t, client.Call(&result, "test.unknown"), -32005, "rate limit exceeded for method test.unknown")
+
+	after := rpc.GetRateLimitStats()
+	common.Expect(t, after.LimitedCalls-before.LimitedCalls, uint64(4))
+	common.Expect(t, after.LimitedMethods["test.huge"]-before.LimitedMethods["test.huge"], uint64(1))
+	_, ok := after.LimitedMethods["test.unknown"]
+	common.ExpectTrue(t, !ok)
+}
@@ -1,9 +1,22 @@
 package node
 
+import (
+	"net/http"
+	"time"
+
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+)
+
 // configureRPC is a helper method to configure all the various RPC endpoints during node
 // startup. It's not meant to be called at any time afterwards as it makes certain
 // assumptions about the state of the node.
 func (node *Node) startRPC() error {
+	auth, err := newRPCAuth(node.config.RPC.Auth, node.rpcAPIs)
+	if err != nil {
+		return err
+	}
+	rateLimiter := newRPCRateLimiter(node.config.RPC.RateLimit)
+
 	// Configure HTTP.
 	if node.config.RPC.HTTPHost != "" {
 		config := httpConfig{
@@ -11,6 +24,8 @@ func (node *Node) startRPC() error {
 			Vhosts:             node.config.RPC.HTTPVirtualHosts,
 			Modules:            node.config.RPC.Endpoints,
 			prefix:             "",
+			auth:               auth,
+			rateLimiter:        rateLimiter,
 		}
 		if err := node.http.setListenAddr(node.config.RPC.HTTPHost, node.config.RPC.HTTPPort); err != nil {
 			return err
@@ -18,15 +33,20 @@ func (node *Node) startRPC() error {
 		if err := node.http.enableRPC(node.rpcAPIs, config); err != nil {
 			return err
 		}
+		health := node.newHealthHandler()
+		node.http.registerHandler("health", "/health", http.HandlerFunc(health.serveHealth))
+		node.http.registerHandler("readiness", "/ready", http.HandlerFunc(health.serveReady))
 	}
 
 	// Configure WebSocket.
 	if node.config.RPC.WSHost != "" {
 		server := node.wsServerForPort(node.config.RPC.WSPort)
 		config := wsConfig{
-			Modules: node.config.RPC.Endpoints,
-			Origins: node.config.RPC.WSOrigins,
-			prefix:  "",
+			Modules:     node.config.RPC.Endpoints,
+			Origins:     node.config.RPC.WSOrigins,
+			prefix:      "",
+			auth:        auth,
+			rateLimiter: rateLimiter,
 		}
 		if err := server.setListenAddr(node.config.RPC.WSHost, node.config.RPC.WSPort); err != nil {
 			return err
@@ -39,7 +59,44 @@ func (node *Node) startRPC() error {
 	if err := node.http.start(); err != nil {
 		return err
 	}
-	return node.ws.start()
+	if err := node.ws.start(); err != nil {
+		return err
+	}
+	return node.ipc.start(node.rpcAPIs)
+}
+
+// newHealthHandler applies the defaults to the thresholds of the config
+func (node *Node) newHealthHandler() *healthHandler {
+	minPeers := node.config.RPC.Health.MinPeers
+	if minPeers == 0 {
+		minPeers = node.config.Net.MinPeers
+	}
+	maxMomentumAge := node.config.RPC.Health.MaxMomentumAgeSec
+	if maxMomentumAge == 0 {
+		maxMomentumAge = DefaultMaxMomentumAgeSec
+	}
+	return newHealthHandler(node.z, node.server, minPeers, time.Duration(maxMomentumAge)*time.Second)
+}
+
+// newRPCRateLimiter returns nil if rate limiting is disabled. The configured weights override DefaultRPCWeights.
+func newRPCRateLimiter(config *RPCRateLimitConfig) *rpc.RateLimiter {
+	if config == nil {
+		return nil
+	}
+	weights := make(map[string]float64, len(DefaultRPCWeights)+len(config.Weights))
+	for name, weight := range DefaultRPCWeights {
+		weights[name] = weight
+	}
+	for name, weight := range config.Weights {
+		weights[name] = weight
+	}
+	return rpc.NewRateLimiter(rpc.RateLimitConfig{
+		Rate:       config.Rate,
+		Burst:      config.Burst,
+		NamedRate:  config.TokenRate,
+		NamedBurst: config.TokenBurst,
+		Weights:    weights,
+	})
 }
 
 func (node *Node) wsServerForPort(port int) *httpServer {
@@ -52,4 +109,7 @@ func (node *Node) wsServerForPort(port int) *httpServer {
 func (node *Node) stopRPC() {
 	node.http.stop()
 	node.ws.stop()
+	if err := node.ipc.stop(); err != nil {
+		log.Error("failed to close IPC endpoint", "reason", err)
+	}
 }
@@ -25,14 +25,18 @@ type httpConfig struct {
 	Modules            []string
 	CorsAllowedOrigins []string
 	Vhosts             []string
-	prefix             string // path prefix on which to mount http handler
+	prefix             string           // path prefix on which to mount http handler
+	auth               *rpcAuth         // nil if auth is disabled
+	rateLimiter        *rpc.RateLimiter // nil if rate limiting is disabled
 }
 
 // wsConfig is the JSON-RPC/Websocket configuration
 type wsConfig struct {
-	Origins []string
-	Modules []string
-	prefix  string // path prefix on which to mount ws handler
+	Origins     []string
+	Modules     []string
+	prefix      string           // path prefix on which to mount ws handler
+	auth        *rpcAuth         // nil if auth is disabled
+	rateLimiter *rpc.RateLimiter // nil if rate limiting is disabled
 }
 
 type rpcHandler struct {
@@ -200,6 +204,15 @@ func (h *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotFound)
 }
 
+// registerHandler mounts handler on path, it's served only if http-rpc is enabled
+func (h *httpServer) registerHandler(name, path string, handler http.Handler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.mux.Handle(path, handler)
+	h.handlerNames[path] = name
+}
+
 // checkPath checks whether a given request URL matches a given path prefix.
 func checkPath(r *http.Request, path string) bool {
 	// if no prefix has been specified, request URL must be on root
@@ -270,12 +283,13 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 
 	// Create RPC server and handler.
 	srv := rpc.NewServer()
-	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false); err != nil {
+	srv.SetRateLimiter(config.rateLimiter)
+	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false, config.auth != nil); err != nil {
 		return err
 	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts),
+		Handler: NewHTTPHandlerStack(config.auth.wrap(srv), config.CorsAllowedOrigins, config.Vhosts),
 		server:  srv,
 	})
 	return nil
@@ -302,12 +316,13 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 
 	// Create RPC server and handler.
 	srv := rpc.NewServer()
-	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false); err != nil {
+	srv.SetRateLimiter(config.rateLimiter)
+	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false, config.auth != nil); err != nil {
 		return err
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: srv.WebsocketHandler(config.Origins),
+		Handler: config.auth.wrap(srv.WebsocketHandler(config.Origins)),
 		server:  srv,
 	})
 	return nil
@@ -460,9 +475,62 @@ func newGzipHandler(next http.Handler) http.Handler {
 	})
 }
 
+// ipcServer serves JSON-RPC over a unix socket, or a named pipe on windows.
+// Access is restricted by the file permissions of the socket, so all APIs are exposed, including the non-public ones.
+type ipcServer struct {
+	log      common.Logger
+	endpoint string
+
+	mu       sync.Mutex
+	listener net.Listener
+	srv      *rpc.Server
+}
+
+func newIPCServer(endpoint string) *ipcServer {
+	return &ipcServer{
+		log:      common.NodeLogger.New("submodule", "ipc-server"),
+		endpoint: endpoint,
+	}
+}
+
+// start starts the IPC server if it is configured and not already running.
+func (is *ipcServer) start(apis []rpc.API) error {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	if is.endpoint == "" || is.listener != nil {
+		return nil // already running or not configured
+	}
+	listener, srv, err := rpc.StartIPCEndpoint(is.endpoint, apis)
+	if err != nil {
+		is.log.Warn("IPC opening failed", "url", is.endpoint, "reason", err)
+		return err
+	}
+	is.log.Info("IPC endpoint opened", "url", is.endpoint)
+	is.listener, is.srv = listener, srv
+	return nil
+}
+
+// stop shuts down the IPC server. Closing the listener also removes the socket file.
+func (is *ipcServer) stop() error {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	if is.listener == nil {
+		return nil // not running
+	}
+	err := is.listener.Close()
+	is.srv.Stop()
+	is.listener, is.srv = nil, nil
+	is.log.Info("IPC endpoint closed", "url", is.endpoint)
+	return err
+}
+
 // RegisterApisFromWhitelist checks the given modules' availability, generates a whitelist based on the allowed modules,
 // and then registers all of the APIs exposed by the services.
-func RegisterApisFromWhitelist(apis []rpc.API, modules []string, srv *rpc.Server, exposeAll bool) error {
+// Non-public APIs are registered if exposeAll is set, or if exposePrivate is set and they're whitelisted,
+// in which case the caller is responsible for restricting the access to them.
+func RegisterApisFromWhitelist(apis []rpc.API, modules []string, srv *rpc.Server, exposeAll, exposePrivate bool) error {
 	if bad, available := checkModuleAvailability(modules, apis); len(bad) > 0 {
 		log.Error("Unavailable modules in HTTP API list", "unavailable", bad, "available", available)
 	}
@@ -473,7 +541,13 @@ func RegisterApisFromWhitelist(apis []rpc.API, modules []string, srv *rpc.Server
 	}
 	// Register all the APIs exposed by the services
 	for _, api := range apis {
-		if exposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
+		if !exposeAll && !exposePrivate && !api.Public {
+			if whitelist[api.Namespace] {
+				log.Warn("Refusing to expose non-public module", "module", api.Namespace)
+			}
+			continue
+		}
+		if exposeAll || whitelist[api.Namespace] || len(whitelist) == 0 {
 			if err := srv.RegisterName(api.Namespace, api.Service); err != nil {
 				return err
 			}
@@ -0,0 +1,109 @@
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/pow"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/wallet"
+)
+
+// Build fills the chain-dependent fields of template from info, the same way the supervisor does for online blocks.
+// If neither FusedPlasma nor Difficulty is set, the base plasma of the block is fused.
+// If Difficulty is set, the nonce is computed, which may take a while.
+func Build(ctx context.Context, info *ChainInfo, template *nom.AccountBlock) (*nom.AccountBlock, error) {
+	block := template.Copy()
+	if block.Address.IsZero() {
+		block.Address = info.Address
+	} else if block.Address != info.Address {
+		return nil, errors.Errorf("chain-info belongs to %v instead of %v", info.Address, block.Address)
+	}
+
+	switch block.BlockType {
+	case nom.BlockTypeUserSend:
+		if block.Amount == nil {
+			block.Amount = big.NewInt(0)
+		}
+	case nom.BlockTypeUserReceive:
+		if block.FromBlockHash.IsZero() {
+			return nil, errors.New("receive-blocks require the hash of the send-block")
+		}
+		block.Amount = common.Big0
+		block.TokenStandard = types.ZeroTokenStandard
+	default:
+		return nil, errors.Errorf("unsupported block type %v", block.BlockType)
+	}
+
+	if block.Version == 0 {
+		block.Version = 1
+	}
+	block.ChainIdentifier = info.ChainIdentifier
+	block.PreviousHash = info.Frontier.Hash
+	block.Height = info.Frontier.Height + 1
+	block.MomentumAcknowledged = info.MomentumAcknowledged
+
+	if block.FusedPlasma == 0 && block.Difficulty == 0 {
+		// embedded methods don't depend on the context when computing the base plasma
+		base, err := vm.GetBasePlasmaForAccountBlock(nil, block)
+		if err != nil {
+			return nil, err
+		}
+		block.FusedPlasma = base
+	}
+	if block.Difficulty != 0 {
+		nonce, err := pow.Solve(ctx, pow.GetAccountBlockHash(block), block.Difficulty)
+		if err != nil {
+			return nil, err
+		}
+		block.Nonce = *nonce
+	}
+	return block, nil
+}
+
+// Sign computes the hash of block and signs it with the key-pair of block.Address, found in keyFile.
+func Sign(block *nom.AccountBlock, keyFile *wallet.KeyFile, password string) error {
+	ks, err := keyFile.Decrypt(password)
+	if err != nil {
+		return err
+	}
+	defer ks.Zero()
+	keyPair, _, err := ks.FindAddress(block.Address)
+	if err != nil {
+		return err
+	}
+
+	block.Hash = block.ComputeHash()
+	signature, _, publicKey, err := keyPair.Signer(block.Hash.Bytes())
+	if err != nil {
+		return err
+	}
+	block.Signature = signature
+	block.PublicKey = publicKey
+	return nil
+}
+
+// ReadBlock reads a block in the JSON format used by the ledger RPC.
+func ReadBlock(path string) (*nom.AccountBlock, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block := new(nom.AccountBlock)
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, errors.Errorf("invalid account-block file %v: %v", path, err)
+	}
+	return block, nil
+}
+
+// WriteBlock writes block in the JSON format accepted by ledger.publishRawTransaction.
+func WriteBlock(path string, block *nom.AccountBlock) error {
+	return writeJson(path, block)
+}
@@ -0,0 +1,66 @@
+package offline
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+// ChainInfo holds the chain-dependent fields required to build the next account-block of Address.
+// It's exported on an online machine and copied to the air-gapped one.
+type ChainInfo struct {
+	ChainIdentifier      uint64           `json:"chainIdentifier"`
+	Address              types.Address    `json:"address"`
+	Frontier             types.HashHeight `json:"frontier"` // zero if the account has no blocks
+	MomentumAcknowledged types.HashHeight `json:"momentumAcknowledged"`
+}
+
+// NewChainInfo uses the frontier account-block of address, which is nil for new accounts, and the frontier momentum.
+func NewChainInfo(address types.Address, frontier *nom.AccountBlock, momentum *nom.Momentum) (*ChainInfo, error) {
+	if momentum == nil {
+		return nil, errors.New("missing frontier momentum")
+	}
+	info := &ChainInfo{
+		ChainIdentifier:      momentum.ChainIdentifier,
+		Address:              address,
+		MomentumAcknowledged: momentum.Identifier(),
+	}
+	if frontier != nil {
+		if frontier.Address != address {
+			return nil, errors.Errorf("frontier account-block belongs to %v instead of %v", frontier.Address, address)
+		}
+		info.Frontier = frontier.Identifier()
+	}
+	return info, nil
+}
+
+func ReadChainInfo(path string) (*ChainInfo, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info := new(ChainInfo)
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, errors.Errorf("invalid chain-info file %v: %v", path, err)
+	}
+	if info.MomentumAcknowledged.IsZero() {
+		return nil, errors.Errorf("invalid chain-info file %v: missing momentumAcknowledged", path)
+	}
+	return info, nil
+}
+
+func (info *ChainInfo) Write(path string) error {
+	return writeJson(path, info)
+}
+
+func writeJson(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0600)
+}
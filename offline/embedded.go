@@ -0,0 +1,155 @@
+package offline
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/abi"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+)
+
+type embeddedContract struct {
+	address types.Address
+	abi     abi.ABIContract
+}
+
+var embeddedContracts = map[string]embeddedContract{
+	"pillar":      {types.PillarContract, definition.ABIPillars},
+	"plasma":      {types.PlasmaContract, definition.ABIPlasma},
+	"stake":       {types.StakeContract, definition.ABIStake},
+	"token":       {types.TokenContract, definition.ABIToken},
+	"sentinel":    {types.SentinelContract, definition.ABISentinel},
+	"swap":        {types.SwapContract, definition.ABISwap},
+	"spork":       {types.SporkContract, definition.ABISpork},
+	"liquidity":   {types.LiquidityContract, definition.ABILiquidity},
+	"accelerator": {types.AcceleratorContract, definition.ABIAccelerator},
+}
+
+// EmbeddedContractNames returns the names accepted by PackEmbeddedCall, sorted.
+func EmbeddedContractNames() []string {
+	names := make([]string, 0, len(embeddedContracts))
+	for name := range embeddedContracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PackEmbeddedCall returns the address of the embedded contract & the ABI-packed call of method.
+// The arguments are parsed according to the ABI types of the method inputs:
+//   - addresses, token-standards & hashes use their usual string format
+//   - bytes use hex, with or without the 0x prefix
+//   - slices & arrays are comma separated
+func PackEmbeddedCall(contract, method string, args []string) (types.Address, []byte, error) {
+	c, ok := embeddedContracts[strings.ToLower(contract)]
+	if !ok {
+		return types.ZeroAddress, nil, errors.Errorf("unknown embedded contract %q, expected one of %v", contract, EmbeddedContractNames())
+	}
+	m, ok := c.abi.Methods[method]
+	if !ok {
+		return types.ZeroAddress, nil, errors.Errorf("unknown method %q for the %v contract", method, contract)
+	}
+	if len(args) != len(m.Inputs) {
+		return types.ZeroAddress, nil, errors.Errorf("method %v expects %v arguments but got %v", m.Sig(), len(m.Inputs), len(args))
+	}
+
+	values := make([]interface{}, len(args))
+	for i, input := range m.Inputs {
+		value, err := parseArgument(input.Type, args[i])
+		if err != nil {
+			return types.ZeroAddress, nil, errors.Errorf("invalid argument %v (%v %v): %v", i, input.Name, input.Type, err)
+		}
+		values[i] = value.Interface()
+	}
+	data, err := c.abi.PackMethod(method, values...)
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+	return c.address, data, nil
+}
+
+func parseArgument(t abi.Type, s string) (reflect.Value, error) {
+	switch t.T {
+	case abi.SliceTy, abi.ArrayTy:
+		var items []string
+		if s != "" {
+			items = strings.Split(s, ",")
+		}
+		var value reflect.Value
+		if t.T == abi.SliceTy {
+			value = reflect.MakeSlice(t.Type, len(items), len(items))
+		} else if len(items) != t.Size {
+			return reflect.Value{}, errors.Errorf("expected %v items but got %v", t.Size, len(items))
+		} else {
+			value = reflect.New(t.Type).Elem()
+		}
+		for i, item := range items {
+			elem, err := parseArgument(*t.Elem, strings.TrimSpace(item))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value.Index(i).Set(elem)
+		}
+		return value, nil
+	case abi.IntTy, abi.UintTy:
+		if t.Kind == reflect.Ptr {
+			value, ok := new(big.Int).SetString(s, 10)
+			if !ok {
+				return reflect.Value{}, errors.Errorf("invalid number %q", s)
+			}
+			return reflect.ValueOf(value), nil
+		}
+		value := reflect.New(t.Type).Elem()
+		if t.T == abi.UintTy {
+			n, err := strconv.ParseUint(s, 10, t.Size)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value.SetUint(n)
+		} else {
+			n, err := strconv.ParseInt(s, 10, t.Size)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value.SetInt(n)
+		}
+		return value, nil
+	case abi.BoolTy:
+		value, err := strconv.ParseBool(s)
+		return reflect.ValueOf(value), err
+	case abi.StringTy:
+		return reflect.ValueOf(s), nil
+	case abi.AddressTy:
+		value, err := types.ParseAddress(s)
+		return reflect.ValueOf(value), err
+	case abi.TokenStandardTy:
+		value, err := types.ParseZTS(s)
+		return reflect.ValueOf(value), err
+	case abi.HashTy:
+		value, err := types.HexToHash(s)
+		return reflect.ValueOf(value), err
+	case abi.BytesTy, abi.FixedBytesTy:
+		data, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if t.T == abi.BytesTy {
+			return reflect.ValueOf(data), nil
+		}
+		if len(data) != t.Size {
+			return reflect.Value{}, errors.Errorf("expected %v bytes but got %v", t.Size, len(data))
+		}
+		value := reflect.New(t.Type).Elem()
+		reflect.Copy(value, reflect.ValueOf(data))
+		return value, nil
+	default:
+		return reflect.Value{}, errors.Errorf("unsupported type %v", t)
+	}
+}
@@ -24,11 +24,12 @@ import (
 	"github.com/ethereum/go-ethereum/metrics"
 )
 
+// The meters are forced, since metrics can be enabled after the package is loaded
 var (
-	ingressConnectMeter = metrics.NewMeter()
-	ingressTrafficMeter = metrics.NewMeter()
-	egressConnectMeter  = metrics.NewMeter()
-	egressTrafficMeter  = metrics.NewMeter()
+	ingressConnectMeter = metrics.NewRegisteredMeterForced("p2p/ingress/connections", nil)
+	ingressTrafficMeter = metrics.NewRegisteredMeterForced("p2p/ingress/bytes", nil)
+	egressConnectMeter  = metrics.NewRegisteredMeterForced("p2p/egress/connections", nil)
+	egressTrafficMeter  = metrics.NewRegisteredMeterForced("p2p/egress/bytes", nil)
 )
 
 // meteredConn is a wrapper around a network TCP connection that meters both the
@@ -135,8 +135,13 @@ type Server struct {
 	peerOp     chan peerOpFunc
 	peerOpDone chan struct{}
 
+	// banned nodes are refused until the server restarts
+	bannedLock sync.RWMutex
+	banned     map[discover.NodeID]bool
+
 	quit          chan struct{}
 	addstatic     chan *discover.Node
+	removepeer    chan discover.NodeID
 	posthandshake chan *conn
 	addpeer       chan *conn
 	delpeer       chan *Peer
@@ -252,6 +257,50 @@ func (srv *Server) AddPeer(node *discover.Node) {
 	}
 }
 
+// RemovePeer disconnects from the node with the given ID and stops maintaining the connection if it was added
+// with AddPeer or as a static node.
+func (srv *Server) RemovePeer(id discover.NodeID) {
+	select {
+	case srv.removepeer <- id:
+	case <-srv.quit:
+	}
+}
+
+// BanPeer removes the node with the given ID & refuses all connections from or to it until the server restarts.
+func (srv *Server) BanPeer(id discover.NodeID) {
+	srv.bannedLock.Lock()
+	if srv.banned == nil {
+		srv.banned = make(map[discover.NodeID]bool)
+	}
+	srv.banned[id] = true
+	srv.bannedLock.Unlock()
+	srv.RemovePeer(id)
+}
+
+// UnbanPeer allows connections from or to the node with the given ID again.
+func (srv *Server) UnbanPeer(id discover.NodeID) {
+	srv.bannedLock.Lock()
+	defer srv.bannedLock.Unlock()
+	delete(srv.banned, id)
+}
+
+// BannedPeers returns the IDs of all banned nodes.
+func (srv *Server) BannedPeers() []discover.NodeID {
+	srv.bannedLock.RLock()
+	defer srv.bannedLock.RUnlock()
+	ids := make([]discover.NodeID, 0, len(srv.banned))
+	for id := range srv.banned {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (srv *Server) isBanned(id discover.NodeID) bool {
+	srv.bannedLock.RLock()
+	defer srv.bannedLock.RUnlock()
+	return srv.banned[id]
+}
+
 // Self returns the local node's endpoint information.
 func (srv *Server) Self() *discover.Node {
 	srv.lock.Lock()
@@ -322,6 +371,7 @@ func (srv *Server) Start() (err error) {
 	srv.delpeer = make(chan *Peer)
 	srv.posthandshake = make(chan *conn)
 	srv.addstatic = make(chan *discover.Node)
+	srv.removepeer = make(chan discover.NodeID)
 	srv.peerOp = make(chan peerOpFunc)
 	srv.peerOpDone = make(chan struct{})
 
@@ -387,6 +437,7 @@ type dialer interface {
 	newTasks(running int, peers map[discover.NodeID]*Peer, now time.Time) []task
 	taskDone(task, time.Time)
 	addStatic(*discover.Node)
+	removeStatic(discover.NodeID)
 }
 
 func (srv *Server) run(dialstate dialer) {
@@ -451,6 +502,13 @@ running:
 			// it will keep the node connected.
 			common.P2PLogger.Debug(fmt.Sprintf("<-addstatic:", n))
 			dialstate.addStatic(n)
+		case id := <-srv.removepeer:
+			// This channel is used by RemovePeer & BanPeer.
+			common.P2PLogger.Debug(fmt.Sprintf("<-removepeer: %v", id))
+			dialstate.removeStatic(id)
+			if p := peers[id]; p != nil {
+				p.Disconnect(DiscRequested)
+			}
 		case op := <-srv.peerOp:
 			// This channel is used by Peers and PeerCount.
 			op(peers)
@@ -531,6 +589,8 @@ func (srv *Server) encHandshakeChecks(peers map[discover.NodeID]*Peer, c *conn)
 		return DiscTooManyPeers
 	case peers[c.id] != nil:
 		return DiscAlreadyConnected
+	case srv.isBanned(c.id):
+		return DiscUselessPeer
 	case c.id == srv.Self().ID:
 		return DiscSelf
 	default:
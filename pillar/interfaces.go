@@ -1,6 +1,8 @@
 package pillar
 
 import (
+	"time"
+
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/consensus"
@@ -22,4 +24,8 @@ type Manager interface {
 
 	SetCoinBase(coinbase *wallet.KeyPair)
 	GetCoinBase() *types.Address
+
+	// ProduceOnDemand makes the producer skip its events while there are no new account blocks.
+	// If emptyPeriod is not 0, an empty momentum is still produced once the frontier momentum is older than it.
+	ProduceOnDemand(emptyPeriod time.Duration)
 }
@@ -21,6 +21,10 @@ type manager struct {
 
 	worker *worker
 
+	onDemand    bool
+	emptyPeriod time.Duration
+
+	chain       chain.Chain
 	consensus   consensus.Consensus
 	broadcaster protocol.Broadcaster
 }
@@ -28,6 +32,7 @@ type manager struct {
 func NewPillar(chain chain.Chain, consensus consensus.Consensus, broadcaster protocol.Broadcaster) Manager {
 	supervisor := vm.NewSupervisor(chain, consensus)
 	return &manager{
+		chain:       chain,
 		consensus:   consensus,
 		broadcaster: broadcaster,
 		worker:      newWorker(chain, supervisor, broadcaster),
@@ -89,11 +94,29 @@ func (m *manager) shouldProcess(e consensus.ProducerEvent) error {
 	if common.Clock.Now().After(e.EndTime) {
 		return ErrEventEnded
 	}
+	if m.onDemand && len(m.chain.GetNewMomentumContent()) == 0 {
+		if m.emptyPeriod == 0 {
+			return ErrNothingToProduce
+		}
+		frontier, err := m.chain.GetFrontierMomentumStore().GetFrontierMomentum()
+		if err != nil {
+			return err
+		}
+		if e.StartTime.Sub(*frontier.Timestamp) < m.emptyPeriod {
+			return ErrNothingToProduce
+		}
+	}
 	return nil
 }
 func (m *manager) processSupervised(e consensus.ProducerEvent) {
 	if err := m.shouldProcess(e); err != nil {
+		if err == ErrNothingToProduce {
+			return // happens on every event of an idle dev chain
+		}
 		m.log.Info("do not process current event", "event", e, "reason", err)
+		if (err == ErrSyncNotDone || err == ErrEventEnded) && m.coinbase != nil && m.coinbase.Address == e.Producer {
+			missedMomentumsCounter.Inc(1)
+		}
 		return
 	}
 
@@ -139,3 +162,7 @@ func (m *manager) GetCoinBase() *types.Address {
 	}
 	return &m.coinbase.Address
 }
+func (m *manager) ProduceOnDemand(emptyPeriod time.Duration) {
+	m.onDemand = true
+	m.emptyPeriod = emptyPeriod
+}
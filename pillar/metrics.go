@@ -0,0 +1,11 @@
+package pillar
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// momentums of the local pillar, always collected
+var (
+	producedMomentumsCounter = metrics.NewRegisteredCounterForced("pillar/momentums/produced", nil)
+	missedMomentumsCounter   = metrics.NewRegisteredCounterForced("pillar/momentums/missed", nil)
+)
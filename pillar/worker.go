@@ -93,6 +93,13 @@ func (w *worker) Process(e consensus.ProducerEvent) common.Task {
 func (w *worker) work(task common.TaskResolver, e consensus.ProducerEvent) {
 	var momentumStore store.Momentum
 
+	produced := false
+	defer func() {
+		if !produced {
+			missedMomentumsCounter.Inc(1)
+		}
+	}()
+
 	w.log.Info("producing momentum", "event", e)
 	momentum, err := w.generateMomentum(e)
 	if err != nil {
@@ -111,6 +118,8 @@ func (w *worker) work(task common.TaskResolver, e consensus.ProducerEvent) {
 	} else {
 		w.log.Info("broadcasting own momentum", "identifier", momentum.Momentum.Identifier())
 		w.broadcaster.CreateMomentum(momentum)
+		producedMomentumsCounter.Inc(1)
+		produced = true
 	}
 
 	if task.ShouldStop() {
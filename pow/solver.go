@@ -0,0 +1,93 @@
+package pow
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common/crypto"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+var (
+	ErrSolveCanceled = errors.New("pow solve canceled")
+)
+
+// Solve searches for a nonce which satisfies difficulty for dataHash.
+// The search runs on all available cpu-cores and stops as soon as ctx is done.
+func Solve(ctx context.Context, dataHash types.Hash, difficulty uint64) (*nom.Nonce, error) {
+	return SolveWithThreads(ctx, dataHash, difficulty, runtime.NumCPU())
+}
+
+// SolveWithThreads is the same as Solve but uses exactly threads workers.
+func SolveWithThreads(ctx context.Context, dataHash types.Hash, difficulty uint64, threads int) (*nom.Nonce, error) {
+	if threads < 1 {
+		threads = 1
+	}
+	target := getTargetByDifficulty(difficulty)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found := make(chan nom.Nonce, threads)
+	wg := new(sync.WaitGroup)
+	for i := 0; i < threads; i += 1 {
+		seed, err := randomSeed()
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if nonce, ok := search(ctx, dataHash, target, seed); ok {
+				found <- nonce
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case nonce := <-found:
+		return &nonce, nil
+	default:
+		return nil, ErrSolveCanceled
+	}
+}
+
+// search iterates nonces starting from seed until one reaches target.
+// ctx is checked every checkInterval iterations in order to keep the hot loop cheap.
+func search(ctx context.Context, dataHash types.Hash, target [8]byte, seed uint64) (nom.Nonce, bool) {
+	const checkInterval = 1 << 12
+
+	calc := make([]byte, 40)
+	copy(calc[8:], dataHash[:])
+	for current := seed; ; current += 1 {
+		if current%checkInterval == 0 {
+			select {
+			case <-ctx.Done():
+				return nom.Nonce{}, false
+			default:
+			}
+		}
+		binary.LittleEndian.PutUint64(calc[:8], current)
+		if greaterDifficulty(crypto.Hash(calc)[:8], target[:]) {
+			nonce := nom.Nonce{}
+			copy(nonce.Data[:], calc[:8])
+			return nonce, true
+		}
+	}
+}
+
+func randomSeed() (uint64, error) {
+	seed := make([]byte, 8)
+	if _, err := rand.Read(seed); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(seed), nil
+}
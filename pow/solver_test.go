@@ -0,0 +1,31 @@
+package pow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+func TestSolve(t *testing.T) {
+	block := &nom.AccountBlock{
+		Address:      types.PlasmaContract,
+		PreviousHash: types.NewHash([]byte("previous")),
+		Difficulty:   31500,
+	}
+	nonce, err := Solve(context.Background(), GetAccountBlockHash(block), block.Difficulty)
+	common.FailIfErr(t, err)
+	block.Nonce = *nonce
+	if !CheckPoWNonce(block) {
+		t.Fatalf("generated nonce %x doesn't satisfy difficulty %v", nonce.Data, block.Difficulty)
+	}
+}
+
+func TestSolve_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := SolveWithThreads(ctx, types.NewHash([]byte("data")), 1<<62, 2)
+	common.ExpectError(t, err, ErrSolveCanceled)
+}
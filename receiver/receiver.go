@@ -0,0 +1,240 @@
+package receiver
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/pow"
+	"github.com/zenon-network/go-zenon/protocol"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/wallet"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+const (
+	// number of unreceived blocks which are checked for one address on each run.
+	// Blocks which don't pass the filters count towards the limit.
+	unreceivedQuerySize = 1000
+	// number of receive-blocks generated for one address on each run
+	maxReceivesPerRun = 50
+)
+
+type Config struct {
+	Addresses      []types.Address
+	TokenStandards []types.ZenonTokenStandard // if empty, all tokens are received
+	MinAmount      *big.Int                   // if nil, all amounts are received
+}
+
+// Receiver generates receive-blocks for the unreceived account-blocks of the configured addresses.
+// Only addresses found in unlocked keyFiles are handled, the others are skipped until they get unlocked.
+type Receiver interface {
+	// MomentumEventListener is used to wake up the receiver when new send-blocks are confirmed
+	chain.MomentumEventListener
+
+	Start() error
+	Stop() error
+}
+
+type receiver struct {
+	log         common.Logger
+	config      *Config
+	chain       chain.Chain
+	supervisor  *vm.Supervisor
+	broadcaster protocol.Broadcaster
+	manager     *wallet.Manager
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewReceiver(config *Config, z zenon.Zenon, manager *wallet.Manager) Receiver {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &receiver{
+		log:         common.ReceiverLogger,
+		config:      config,
+		chain:       z.Chain(),
+		supervisor:  vm.NewSupervisor(z.Chain(), z.Consensus()),
+		broadcaster: z.Broadcaster(),
+		manager:     manager,
+		wake:        make(chan struct{}, 1),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+func (r *receiver) Start() error {
+	r.log.Info("starting ...", "addresses", r.config.Addresses, "token-standards", r.config.TokenStandards, "min-amount", r.config.MinAmount)
+	r.chain.Register(r)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer common.RecoverStack()
+		for {
+			r.receiveAll()
+
+			select {
+			case <-r.wake:
+			case <-r.ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+func (r *receiver) Stop() error {
+	r.log.Info("stopping ...")
+	r.chain.UnRegister(r)
+	r.cancel()
+	r.wg.Wait()
+	return nil
+}
+
+func (r *receiver) InsertMomentum(*nom.DetailedMomentum) {
+	r.notify()
+}
+func (r *receiver) DeleteMomentum(*nom.DetailedMomentum) {
+}
+func (r *receiver) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *receiver) receiveAll() {
+	if r.broadcaster.SyncInfo().State != protocol.SyncDone {
+		return
+	}
+	for _, address := range r.config.Addresses {
+		if err := r.receive(address); err != nil {
+			r.log.Error("failed to receive blocks", "address", address, "reason", err)
+		}
+		if r.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (r *receiver) receive(address types.Address) error {
+	keyPair, err := r.manager.FindUnlockedKeyPair(address)
+	if err == wallet.ErrAddressNotFound {
+		r.log.Debug("skipping locked address", "address", address)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	momentumStore := r.chain.GetFrontierMomentumStore()
+	hashes, err := momentumStore.GetAccountMailbox(address).GetUnreceivedAccountBlockHashes(unreceivedQuerySize)
+	if err != nil {
+		return err
+	}
+
+	// skip blocks which are already received by uncommitted blocks
+	pending := make(map[types.Hash]bool)
+	for _, block := range r.chain.GetUncommittedAccountBlocksByAddress(address) {
+		if block.IsReceiveBlock() {
+			pending[block.FromBlockHash] = true
+		}
+	}
+
+	received := 0
+	for _, hash := range hashes {
+		if pending[hash] {
+			continue
+		}
+		sendBlock, err := momentumStore.GetAccountBlockByHash(hash)
+		if err != nil {
+			return err
+		}
+		if sendBlock == nil || !r.shouldReceive(sendBlock) {
+			continue
+		}
+		if err := r.receiveBlock(keyPair, sendBlock); err != nil {
+			return err
+		}
+		received += 1
+		if received == maxReceivesPerRun || r.ctx.Err() != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (r *receiver) shouldReceive(sendBlock *nom.AccountBlock) bool {
+	if len(r.config.TokenStandards) != 0 {
+		allowed := false
+		for _, zts := range r.config.TokenStandards {
+			if zts == sendBlock.TokenStandard {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	if r.config.MinAmount != nil && sendBlock.Amount.Cmp(r.config.MinAmount) == -1 {
+		return false
+	}
+	return true
+}
+
+func (r *receiver) receiveBlock(keyPair *wallet.KeyPair, sendBlock *nom.AccountBlock) error {
+	template := &nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserReceive,
+		Address:       keyPair.Address,
+		FromBlockHash: sendBlock.Hash,
+	}
+	if err := r.setPlasma(template); err != nil {
+		return err
+	}
+	transaction, err := r.supervisor.GenerateFromTemplate(template, keyPair.Signer)
+	if err != nil {
+		return err
+	}
+	r.broadcaster.CreateAccountBlock(transaction)
+	r.log.Info("published receive-block", "identifier", transaction.Block.Header(), "send-block-hash", sendBlock.Hash, "difficulty", transaction.Block.Difficulty)
+	return nil
+}
+
+// setPlasma uses the fused plasma of the address if there is enough of it, otherwise the missing plasma is covered by PoW.
+func (r *receiver) setPlasma(template *nom.AccountBlock) error {
+	momentumStore := r.chain.GetFrontierMomentumStore()
+	accountStore := r.chain.GetFrontierAccountStore(template.Address)
+	available, err := vm.AvailablePlasma(momentumStore, accountStore)
+	if err != nil {
+		return err
+	}
+	if available >= constants.AccountBlockBasePlasma {
+		// the supervisor fuses the base plasma
+		return nil
+	}
+
+	difficulty, err := vm.GetDifficultyForPlasma(constants.AccountBlockBasePlasma - available)
+	if err != nil {
+		return err
+	}
+	frontier := accountStore.Identifier()
+	template.PreviousHash = frontier.Hash
+	template.Height = frontier.Height + 1
+	template.FusedPlasma = available
+	template.Difficulty = difficulty.Uint64()
+
+	r.log.Info("generating PoW for receive-block", "address", template.Address, "difficulty", template.Difficulty)
+	nonce, err := pow.Solve(r.ctx, pow.GetAccountBlockHash(template), template.Difficulty)
+	if err != nil {
+		return err
+	}
+	template.Nonce = *nonce
+	return nil
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/p2p/discover"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+var (
+	ErrInvalidNodeParam     = common.NewErrorWCode(-32000, "invalid node parameter")
+	ErrInvalidLogLevelParam = common.NewErrorWCode(-32000, "invalid log-level parameter")
+)
+
+// AdminApi manages the peers & the process of the node.
+// It is not public and must only be served over IPC or authenticated endpoints.
+type AdminApi struct {
+	z        zenon.Zenon
+	p2p      *p2p.Server
+	dataPath string
+	shutdown func()
+	log      log15.Logger
+}
+
+func NewAdminApi(z zenon.Zenon, p2p *p2p.Server, dataPath string, shutdown func()) *AdminApi {
+	return &AdminApi{
+		z:        z,
+		p2p:      p2p,
+		dataPath: dataPath,
+		shutdown: shutdown,
+		log:      common.RPCLogger.New("module", "admin_api"),
+	}
+}
+
+// parseNodeID accepts enode URLs & hex encoded node IDs
+func parseNodeID(node string) (discover.NodeID, error) {
+	if strings.HasPrefix(node, "enode://") {
+		n, err := discover.ParseNode(node)
+		if err != nil {
+			return discover.NodeID{}, ErrInvalidNodeParam.AddDetail(err.Error())
+		}
+		return n.ID, nil
+	}
+	id, err := discover.HexID(node)
+	if err != nil {
+		return discover.NodeID{}, ErrInvalidNodeParam.AddDetail(err.Error())
+	}
+	return id, nil
+}
+
+// AddPeer connects to the enode URL & keeps the connection until the node restarts or the peer is removed
+func (a *AdminApi) AddPeer(enode string) error {
+	node, err := discover.ParseNode(enode)
+	if err != nil {
+		return ErrInvalidNodeParam.AddDetail(err.Error())
+	}
+	a.log.Info("adding peer", "node", node)
+	a.p2p.AddPeer(node)
+	return nil
+}
+func (a *AdminApi) RemovePeer(node string) error {
+	id, err := parseNodeID(node)
+	if err != nil {
+		return err
+	}
+	a.log.Info("removing peer", "id", id)
+	a.p2p.RemovePeer(id)
+	return nil
+}
+
+// BanPeer disconnects the node & refuses its connections until the node restarts
+func (a *AdminApi) BanPeer(node string) error {
+	id, err := parseNodeID(node)
+	if err != nil {
+		return err
+	}
+	a.log.Info("banning peer", "id", id)
+	a.p2p.BanPeer(id)
+	return nil
+}
+func (a *AdminApi) UnbanPeer(node string) error {
+	id, err := parseNodeID(node)
+	if err != nil {
+		return err
+	}
+	a.log.Info("unbanning peer", "id", id)
+	a.p2p.UnbanPeer(id)
+	return nil
+}
+func (a *AdminApi) GetBannedPeers() ([]string, error) {
+	banned := a.p2p.BannedPeers()
+	ids := make([]string, len(banned))
+	for i, id := range banned {
+		ids[i] = id.String()
+	}
+	return ids, nil
+}
+
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
+
+func (a *AdminApi) GetLogLevel() (*LogLevelResponse, error) {
+	return &LogLevelResponse{Level: common.GetLogLevel()}, nil
+}
+
+// SetLogLevel changes the level of the log file until the node restarts
+func (a *AdminApi) SetLogLevel(level string) error {
+	if err := common.SetLogLevel(level); err != nil {
+		return ErrInvalidLogLevelParam.AddDetail(err.Error())
+	}
+	a.log.Info("changed log level", "level", level)
+	return nil
+}
+
+type DataInfoResponse struct {
+	DataPath string           `json:"dataPath"`
+	Size     int64            `json:"size"`
+	Dirs     map[string]int64 `json:"dirs"` // size in bytes of each directory in DataPath, like the databases
+}
+
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func (a *AdminApi) GetDataInfo() (*DataInfoResponse, error) {
+	entries, err := ioutil.ReadDir(a.dataPath)
+	if err != nil {
+		return nil, err
+	}
+	response := &DataInfoResponse{
+		DataPath: a.dataPath,
+		Dirs:     make(map[string]int64),
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			response.Size += entry.Size()
+			continue
+		}
+		size, err := dirSize(filepath.Join(a.dataPath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		response.Dirs[entry.Name()] = size
+		response.Size += size
+	}
+	return response, nil
+}
+
+type ProducerInfoResponse struct {
+	Coinbase   *types.Address `json:"coinbase"`   // nil if no producer is configured
+	PillarName string         `json:"pillarName"` // empty if the coinbase isn't the producing address of a pillar
+}
+
+func (a *AdminApi) GetProducerInfo() (*ProducerInfoResponse, error) {
+	response := &ProducerInfoResponse{}
+	if producer := a.z.Producer(); producer != nil {
+		response.Coinbase = producer.GetCoinBase()
+	}
+	if response.Coinbase == nil {
+		return response, nil
+	}
+
+	_, context, err := GetFrontierContext(a.z.Chain(), types.PillarContract)
+	if err != nil {
+		return nil, err
+	}
+	pillars, err := definition.GetPillarsList(context.Storage(), true, definition.AnyPillarType)
+	if err != nil {
+		return nil, err
+	}
+	for _, pillar := range pillars {
+		if pillar.BlockProducingAddress == *response.Coinbase {
+			response.PillarName = pillar.Name
+		}
+	}
+	return response, nil
+}
+
+// Shutdown stops the node gracefully, the call returns before the node is stopped
+func (a *AdminApi) Shutdown() error {
+	a.log.Warn("shutdown requested")
+	a.shutdown()
+	return nil
+}
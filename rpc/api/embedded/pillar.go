@@ -19,6 +19,7 @@ import (
 type PillarApi struct {
 	log            log15.Logger
 	chain          chain.Chain
+	z              zenon.Zenon
 	consensusCache ConsensusCache
 }
 
@@ -26,6 +27,7 @@ func NewPillarApi(z zenon.Zenon, testing bool) *PillarApi {
 	return &PillarApi{
 		log:            common.RPCLogger.New("module", "embedded_pillar_api"),
 		chain:          z.Chain(),
+		z:              z,
 		consensusCache: NewConsensusCache(z, testing),
 	}
 }
@@ -36,12 +38,13 @@ var (
 )
 
 // === Shared RPCs ===
+// RPCs which accept an optional momentumHeight answer with the state confirmed by the momentum at that height.
 
-func (a *PillarApi) GetDepositedQsr(address types.Address) (*big.Int, error) {
-	return getDepositedQsr(a.chain, types.PillarContract, address)
+func (a *PillarApi) GetDepositedQsr(address types.Address, momentumHeight *uint64) (*big.Int, error) {
+	return getDepositedQsr(a.z, types.PillarContract, address, momentumHeight)
 }
-func (a *PillarApi) GetUncollectedReward(address types.Address) (*definition.RewardDeposit, error) {
-	return getUncollectedReward(a.chain, types.PillarContract, address)
+func (a *PillarApi) GetUncollectedReward(address types.Address, momentumHeight *uint64) (*definition.RewardDeposit, error) {
+	return getUncollectedReward(a.z, types.PillarContract, address, momentumHeight)
 }
 func (a *PillarApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32) (*RewardHistoryList, error) {
 	if pageSize > api.RpcMaxPageSize {
@@ -50,8 +53,8 @@ func (a *PillarApi) GetFrontierRewardByPage(address types.Address, pageIndex, pa
 	return getFrontierRewardByPage(a.chain, types.PillarContract, address, pageIndex, pageSize)
 }
 
-func (a *PillarApi) GetQsrRegistrationCost() (*big.Int, error) {
-	_, context, err := api.GetFrontierContext(a.chain, types.PillarContract)
+func (a *PillarApi) GetQsrRegistrationCost(momentumHeight *uint64) (*big.Int, error) {
+	_, context, err := api.GetContext(a.z, types.PillarContract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -210,8 +213,8 @@ func (a *PillarApi) GetByName(name string) (*PillarInfo, error) {
 	return nil, nil
 }
 
-func (a *PillarApi) CheckNameAvailability(name string) (bool, error) {
-	_, context, err := api.GetFrontierContext(a.chain, types.PillarContract)
+func (a *PillarApi) CheckNameAvailability(name string, momentumHeight *uint64) (bool, error) {
+	_, context, err := api.GetContext(a.z, types.PillarContract, momentumHeight)
 	if err != nil {
 		return false, err
 	}
@@ -237,8 +240,8 @@ type GetDelegatedPillarResponse struct {
 	Balance    *big.Int `json:"weight"`
 }
 
-func (a *PillarApi) GetDelegatedPillar(addr types.Address) (*GetDelegatedPillarResponse, error) {
-	_, context, err := api.GetFrontierContext(a.chain, types.PillarContract)
+func (a *PillarApi) GetDelegatedPillar(addr types.Address, momentumHeight *uint64) (*GetDelegatedPillarResponse, error) {
+	_, context, err := api.GetContext(a.z, types.PillarContract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -250,7 +253,7 @@ func (a *PillarApi) GetDelegatedPillar(addr types.Address) (*GetDelegatedPillarR
 		return nil, err
 	}
 	if delegationInfo != nil {
-		balance, err := a.chain.GetFrontierMomentumStore().GetAccountStore(addr).GetBalance(types.ZnnTokenStandard)
+		balance, err := context.MomentumStore().GetAccountStore(addr).GetBalance(types.ZnnTokenStandard)
 		if err != nil {
 			return nil, err
 		}
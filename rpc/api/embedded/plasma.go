@@ -62,13 +62,13 @@ func (a SortFusionEntryByHeight) Less(i, j int) bool {
 	return a[i].ExpirationHeight < a[j].ExpirationHeight
 }
 
-func (a *PlasmaApi) Get(address types.Address) (*PlasmaInfo, error) {
-	_, context, err := api.GetFrontierContext(a.chain, address)
+func (a *PlasmaApi) Get(address types.Address, momentumHeight *uint64) (*PlasmaInfo, error) {
+	_, context, err := api.GetContext(a.z, address, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
 
-	amount, err := a.chain.GetFrontierMomentumStore().GetStakeBeneficialAmount(address)
+	amount, err := context.MomentumStore().GetStakeBeneficialAmount(address)
 	if err != nil {
 		return nil, err
 	}
@@ -84,12 +84,12 @@ func (a *PlasmaApi) Get(address types.Address) (*PlasmaInfo, error) {
 		QsrAmount:     amount,
 	}, nil
 }
-func (a *PlasmaApi) GetEntriesByAddress(address types.Address, pageIndex, pageSize uint32) (*FusionEntryList, error) {
+func (a *PlasmaApi) GetEntriesByAddress(address types.Address, pageIndex, pageSize uint32, momentumHeight *uint64) (*FusionEntryList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(a.chain, types.PlasmaContract)
+	_, context, err := api.GetContext(a.z, types.PlasmaContract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ import (
 
 type SentinelApi struct {
 	chain chain.Chain
+	z     zenon.Zenon
 	log   log15.Logger
 }
 
@@ -34,6 +35,7 @@ type SentinelInfoList struct {
 func NewSentinelApi(z zenon.Zenon) *SentinelApi {
 	return &SentinelApi{
 		chain: z.Chain(),
+		z:     z,
 		log:   common.RPCLogger.New("module", "embedded_sentinel_api"),
 	}
 }
@@ -94,10 +96,10 @@ func (api *SentinelApi) GetAllActive(pageIndex, pageSize uint32) (*SentinelInfoL
 // === Shared RPCs ===
 
 func (api *SentinelApi) GetDepositedQsr(address types.Address) (*big.Int, error) {
-	return getDepositedQsr(api.chain, types.SentinelContract, address)
+	return getDepositedQsr(api.z, types.SentinelContract, address, nil)
 }
 func (api *SentinelApi) GetUncollectedReward(address types.Address) (*definition.RewardDeposit, error) {
-	return getUncollectedReward(api.chain, types.SentinelContract, address)
+	return getUncollectedReward(api.z, types.SentinelContract, address, nil)
 }
 func (api *SentinelApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32) (*RewardHistoryList, error) {
 	if pageSize > rpcapi.RpcMaxPageSize {
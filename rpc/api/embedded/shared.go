@@ -7,10 +7,11 @@ import (
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/rpc/api"
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon"
 )
 
-func getDepositedQsr(chain chain.Chain, contract types.Address, address types.Address) (*big.Int, error) {
-	_, context, err := api.GetFrontierContext(chain, contract)
+func getDepositedQsr(z zenon.Zenon, contract types.Address, address types.Address, momentumHeight *uint64) (*big.Int, error) {
+	_, context, err := api.GetContext(z, contract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +22,8 @@ func getDepositedQsr(chain chain.Chain, contract types.Address, address types.Ad
 		return qsrDeposit.Qsr, nil
 	}
 }
-func getUncollectedReward(chain chain.Chain, contract types.Address, address types.Address) (*definition.RewardDeposit, error) {
-	_, context, err := api.GetFrontierContext(chain, contract)
+func getUncollectedReward(z zenon.Zenon, contract types.Address, address types.Address, momentumHeight *uint64) (*definition.RewardDeposit, error) {
+	_, context, err := api.GetContext(z, contract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -33,8 +33,8 @@ func NewStakeApi(z zenon.Zenon) *StakeApi {
 
 // === Shared RPCs ===
 
-func (a *StakeApi) GetUncollectedReward(address types.Address) (*definition.RewardDeposit, error) {
-	return getUncollectedReward(a.chain, types.StakeContract, address)
+func (a *StakeApi) GetUncollectedReward(address types.Address, momentumHeight *uint64) (*definition.RewardDeposit, error) {
+	return getUncollectedReward(a.z, types.StakeContract, address, momentumHeight)
 }
 func (a *StakeApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32) (*RewardHistoryList, error) {
 	if pageSize > api.RpcMaxPageSize {
@@ -58,12 +58,12 @@ type StakeList struct {
 	Entries             []*StakeEntry `json:"list"`
 }
 
-func (a *StakeApi) GetEntriesByAddress(address types.Address, pageIndex, pageSize uint32) (*StakeList, error) {
+func (a *StakeApi) GetEntriesByAddress(address types.Address, pageIndex, pageSize uint32, momentumHeight *uint64) (*StakeList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(a.chain, types.StakeContract)
+	_, context, err := api.GetContext(a.z, types.StakeContract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -34,12 +34,12 @@ type TokenList struct {
 	List  []*api.Token `json:"list"`
 }
 
-func (a *TokenAPI) GetAll(pageIndex, pageSize uint32) (*TokenList, error) {
+func (a *TokenAPI) GetAll(pageIndex, pageSize uint32, momentumHeight *uint64) (*TokenList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(a.chain, types.TokenContract)
+	_, context, err := api.GetContext(a.z, types.TokenContract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -54,12 +54,12 @@ func (a *TokenAPI) GetAll(pageIndex, pageSize uint32) (*TokenList, error) {
 		List:  tokenList[start:end],
 	}, nil
 }
-func (a *TokenAPI) GetByOwner(owner types.Address, pageIndex, pageSize uint32) (*TokenList, error) {
+func (a *TokenAPI) GetByOwner(owner types.Address, pageIndex, pageSize uint32, momentumHeight *uint64) (*TokenList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(a.chain, types.TokenContract)
+	_, context, err := api.GetContext(a.z, types.TokenContract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -82,8 +82,8 @@ func (a *TokenAPI) GetByOwner(owner types.Address, pageIndex, pageSize uint32) (
 		List:  tokenList[start:end],
 	}, nil
 }
-func (a *TokenAPI) GetByZts(zts types.ZenonTokenStandard) (*api.Token, error) {
-	_, context, err := api.GetFrontierContext(a.chain, types.TokenContract)
+func (a *TokenAPI) GetByZts(zts types.ZenonTokenStandard, momentumHeight *uint64) (*api.Token, error) {
+	_, context, err := api.GetContext(a.z, types.TokenContract, momentumHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -10,4 +10,7 @@ var (
 	ErrCountParamTooBig     = common.NewErrorWCode(-32000, "count parameter is too big")
 	ErrHeightParamIsZero    = common.NewErrorWCode(-32000, "height parameter must be strictly greater than zero")
 	ErrParamIsNull          = common.NewErrorWCode(-32000, "parameter must not be null")
+	ErrMomentumHeightTooBig = common.NewErrorWCode(-32000, "momentum-height parameter is higher than the frontier momentum")
+	ErrStateNotAvailable    = common.NewErrorWCode(-32000, "state at the requested momentum-height is no longer available on this node")
+	ErrIndexerDisabled      = common.NewErrorWCode(-32000, "the account-block indexer is disabled on this node")
 )
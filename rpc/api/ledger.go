@@ -11,6 +11,7 @@ import (
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/embedded/events"
 	"github.com/zenon-network/go-zenon/zenon"
 )
 
@@ -73,6 +74,109 @@ func (l *LedgerApi) PublishRawTransaction(block *AccountBlock) error {
 	return nil
 }
 
+// SimulateBlock runs a user send-block, and the embedded receive it triggers, on top of the frontier without publishing anything.
+// The block doesn't need to be signed and the fields which depend on the chain are filled in if missing.
+func (l *LedgerApi) SimulateBlock(block *AccountBlock) (*SimulationResult, error) {
+	defer common.RecoverStack()
+	if block == nil {
+		return nil, ErrParamIsNull
+	}
+
+	lb, err := block.ToLedgerBlock()
+	if err != nil {
+		return nil, err
+	}
+	lb.BlockType = nom.BlockTypeUserSend
+	if err := checkTokenIdValid(l.chain, &lb.TokenStandard); err != nil {
+		return nil, err
+	}
+
+	supervisor := vm.NewSupervisor(l.chain, l.z.Consensus())
+	simulation, err := supervisor.SimulateBlock(lb)
+	if err != nil {
+		return nil, err
+	}
+	return simulationToRpc(l.chain, simulation)
+}
+
+// GetBlockStatus returns the confirmation status of the account-block with the given hash, or null if the block is unknown.
+// For send-blocks it also reports the block which receives it and, for embedded contracts, the execution result.
+func (l *LedgerApi) GetBlockStatus(hash types.Hash) (*BlockStatus, error) {
+	// look in the account-pool before taking the snapshot, so a block confirmed in-between is found in the snapshot
+	uncommitted := l.chain.GetAllUncommittedAccountBlocks()
+	momentumStore := l.chain.GetFrontierMomentumStore()
+
+	var block *nom.AccountBlock
+	inPool := false
+	confirmedBlock, err := momentumStore.GetAccountBlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if confirmedBlock != nil {
+		block = confirmedBlock
+	} else {
+		for _, current := range uncommitted {
+			if current.Hash == hash {
+				block = current
+				inPool = true
+				break
+			}
+		}
+	}
+	if block == nil {
+		return nil, nil
+	}
+
+	status := &BlockStatus{
+		Hash:          block.Hash,
+		Address:       block.Address,
+		BlockType:     block.BlockType,
+		InAccountPool: inPool,
+	}
+	if !inPool {
+		detail, err := getConfirmationDetail(momentumStore, block.Hash)
+		if err != nil {
+			return nil, err
+		}
+		status.ConfirmationDetail = detail
+	}
+
+	if !block.IsSendBlock() {
+		return status, nil
+	}
+
+	receiveBlock, err := momentumStore.GetBlockWhichReceives(block.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if receiveBlock != nil {
+		status.ReceiveConfirmed = true
+	} else {
+		for _, current := range uncommitted {
+			if current.Address == block.ToAddress && current.FromBlockHash == block.Hash {
+				receiveBlock = current
+				break
+			}
+		}
+	}
+	if receiveBlock == nil {
+		return status, nil
+	}
+	status.ReceiveBlockHash = &receiveBlock.Hash
+
+	if types.IsEmbeddedAddress(block.ToAddress) {
+		result := &EmbeddedResult{
+			Success:          vm.EmbeddedReceiveSucceeded(receiveBlock),
+			DescendantBlocks: make([]types.Hash, len(receiveBlock.DescendantBlocks)),
+		}
+		for index, descendant := range receiveBlock.DescendantBlocks {
+			result.DescendantBlocks[index] = descendant.Hash
+		}
+		status.EmbeddedResult = result
+	}
+	return status, nil
+}
+
 // Unconfirmed AccountBlocks
 func (l *LedgerApi) GetUnconfirmedBlocksByAddress(address types.Address, pageIndex, pageSize uint32) (*AccountBlockList, error) {
 	if pageSize > RpcMaxPageSize {
@@ -200,11 +304,63 @@ func (l *LedgerApi) GetAccountBlocksByPage(address types.Address, pageIndex, pag
 	}
 	return ans, nil
 }
-func (l *LedgerApi) GetAccountInfoByAddress(address types.Address) (*AccountInfo, error) {
+
+// GetAccountBlocksByFilter searches the confirmed account-blocks owned by filter.Address, newest first.
+// Unlike GetAccountBlocksByPage, receive-blocks can be matched by the sender & token-standard of the paired send-block.
+// The search stops after the requested page, so Count is the number of matches up to the end of the page, plus one if More is set.
+// Requires the indexer to be enabled.
+func (l *LedgerApi) GetAccountBlocksByFilter(filter *AccountBlockFilter, pageIndex, pageSize uint32) (*AccountBlockList, error) {
+	if filter == nil {
+		return nil, ErrParamIsNull
+	}
+	if pageSize > RpcMaxPageSize {
+		return nil, ErrPageSizeParamTooBig
+	}
+
+	if l.z.Indexer() == nil {
+		return nil, ErrIndexerDisabled
+	}
+	hashes, count, err := l.z.Indexer().GetAccountBlocksByFilter(filter.toIndexerFilter(), pageIndex, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	momentumStore := l.chain.GetFrontierMomentumStore()
+	blocks := make([]*nom.AccountBlock, len(hashes))
+	for index, hash := range hashes {
+		blocks[index], err = momentumStore.GetAccountBlockByHash(hash)
+		if err != nil {
+			l.log.Error("GetAccountBlocksByFilter failed", "reason", err, "method-called", "momentumStore.GetAccountBlockByHash")
+			return nil, err
+		}
+	}
+	list, err := ledgerAccountBlocksToRpc(l.chain, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountBlockList{
+		List:  list,
+		Count: count,
+		More:  uint64(pageIndex+1)*uint64(pageSize) < uint64(count),
+	}, nil
+}
+
+// GetAccountInfoByAddress returns the balances of address at the frontier or, if momentumHeight is set,
+// as they were confirmed by the momentum at momentumHeight.
+func (l *LedgerApi) GetAccountInfoByAddress(address types.Address, momentumHeight *uint64) (*AccountInfo, error) {
 	l.log.Info("GetAccountInfoByAddress")
 
 	momentumStore := l.chain.GetFrontierMomentumStore()
 	accountStore := l.chain.GetFrontierAccountStore(address)
+	if momentumHeight != nil {
+		var err error
+		momentumStore, err = GetMomentumStoreByHeight(l.z, *momentumHeight)
+		if err != nil {
+			return nil, err
+		}
+		accountStore = momentumStore.GetAccountStore(address)
+	}
 	frontierAccountBlock, err := accountStore.Frontier()
 	if err != nil {
 		l.log.Error("GetFrontierAccountBlock failed, error is "+err.Error(), "method", "GetAccountInfoByAddress")
@@ -396,3 +552,57 @@ func (l *LedgerApi) GetDetailedMomentumsByHeight(height, count uint64) (*Detaile
 	}
 	return momentumListToDetailedList(l.chain, ans)
 }
+
+// GetEmbeddedEventsByHeight returns the decoded embedded events of count momentums starting at height
+func (l *LedgerApi) GetEmbeddedEventsByHeight(height, count uint64, filter *events.Filter) (*EmbeddedEventList, error) {
+	if height == 0 {
+		return nil, ErrHeightParamIsZero
+	}
+	if count > RpcMaxCountSize {
+		return nil, ErrCountParamTooBig
+	}
+	if err := filter.Check(); err != nil {
+		return nil, err
+	}
+
+	momentumStore := l.chain.GetFrontierMomentumStore()
+	frontier, err := momentumStore.GetFrontierMomentum()
+	if err != nil {
+		l.log.Error("GetEmbeddedEventsByHeight failed", "reason", err, "method-called", "momentumStore.GetFrontierMomentum")
+		return nil, err
+	}
+	if height > frontier.Height {
+		count = 0
+	} else if height+count > frontier.Height+1 {
+		count = frontier.Height + 1 - height
+	}
+
+	momentums, err := momentumStore.GetMomentumsByHeight(height, true, count)
+	if err != nil {
+		l.log.Error("GetEmbeddedEventsByHeight failed", "reason", err, "method-called", "momentumStore.GetMomentumsByHeight")
+		return nil, err
+	}
+	list := make([]*events.Event, 0)
+	for _, momentum := range momentums {
+		detailed, err := momentumStore.PrefetchMomentum(momentum)
+		if err != nil {
+			l.log.Error("GetEmbeddedEventsByHeight failed", "reason", err, "method-called", "momentumStore.PrefetchMomentum")
+			return nil, err
+		}
+		decoded, err := events.Decode(momentumStore, detailed)
+		if err != nil {
+			l.log.Error("GetEmbeddedEventsByHeight failed", "reason", err, "method-called", "events.Decode")
+			return nil, err
+		}
+		for _, event := range decoded {
+			if filter.Matches(event) {
+				list = append(list, event)
+			}
+		}
+	}
+
+	return &EmbeddedEventList{
+		List:  list,
+		Count: int(frontier.Height),
+	}, nil
+}
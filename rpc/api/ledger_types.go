@@ -2,12 +2,18 @@ package api
 
 import (
 	"math/big"
+	"sort"
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/db"
 	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/indexer"
+	"github.com/zenon-network/go-zenon/vm"
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/vm/embedded/events"
 )
 
 type DetailedMomentum struct {
@@ -64,6 +70,49 @@ type AccountBlockList struct {
 	Count int             `json:"count"`
 	More  bool            `json:"more"`
 }
+
+// AccountBlockFilter selects account-blocks owned by Address. All other fields are optional.
+// Direction is 1 for incoming (receive-blocks) and 2 for outgoing (send-blocks).
+// Timestamps are unix seconds of the confirmation momentum; FromTimestamp is inclusive, ToTimestamp is exclusive.
+type AccountBlockFilter struct {
+	Address       types.Address             `json:"address"`
+	Counterparty  *types.Address            `json:"counterparty"`
+	TokenStandard *types.ZenonTokenStandard `json:"tokenStandard"`
+	BlockType     *uint64                   `json:"blockType"`
+	Direction     *uint8                    `json:"direction"`
+	FromTimestamp *int64                    `json:"fromTimestamp"`
+	ToTimestamp   *int64                    `json:"toTimestamp"`
+}
+
+func (f *AccountBlockFilter) toIndexerFilter() *indexer.Filter {
+	return &indexer.Filter{
+		Address:       f.Address,
+		Counterparty:  f.Counterparty,
+		TokenStandard: f.TokenStandard,
+		BlockType:     f.BlockType,
+		Direction:     f.Direction,
+		FromTimestamp: f.FromTimestamp,
+		ToTimestamp:   f.ToTimestamp,
+	}
+}
+
+// BlockStatus gathers, from one momentum-store snapshot, everything known about the confirmation of an account-block.
+// The receive & embedded related fields are only set for send-blocks.
+type BlockStatus struct {
+	Hash               types.Hash                      `json:"hash"`
+	Address            types.Address                   `json:"address"`
+	BlockType          uint64                          `json:"blockType"`
+	InAccountPool      bool                            `json:"inAccountPool"`
+	ConfirmationDetail *AccountBlockConfirmationDetail `json:"confirmationDetail"`
+	ReceiveBlockHash   *types.Hash                     `json:"receiveBlockHash"`
+	ReceiveConfirmed   bool                            `json:"receiveConfirmed"`
+	EmbeddedResult     *EmbeddedResult                 `json:"embeddedResult"`
+}
+type EmbeddedResult struct {
+	Success          bool         `json:"success"`
+	DescendantBlocks []types.Hash `json:"descendantBlocks"`
+}
+
 type MomentumList struct {
 	List  []*Momentum `json:"list"`
 	Count int         `json:"count"`
@@ -73,6 +122,12 @@ type DetailedMomentumList struct {
 	Count int                 `json:"count"`
 }
 
+// EmbeddedEventList has the same count as MomentumList, the height of the frontier momentum
+type EmbeddedEventList struct {
+	List  []*events.Event `json:"list"`
+	Count int             `json:"count"`
+}
+
 func (block *AccountBlock) ToLedgerBlock() (*nom.AccountBlock, error) {
 	return block.AccountBlock.Copy(), nil
 }
@@ -141,26 +196,37 @@ func (block *AccountBlock) prefetchPaired(chain chain.Chain) error {
 	return nil
 }
 func (block *AccountBlock) addConfirmationInfo(chain chain.Chain) error {
-	store := chain.GetFrontierMomentumStore()
-	frontier, err := store.GetFrontierMomentum()
-	confirmationHeight, err := chain.GetFrontierMomentumStore().GetBlockConfirmationHeight(block.Hash)
+	detail, err := getConfirmationDetail(chain.GetFrontierMomentumStore(), block.Hash)
 	if err != nil {
 		return err
 	}
-	confirmedBlock, err := chain.GetFrontierMomentumStore().GetMomentumByHeight(confirmationHeight)
+	block.ConfirmationDetail = detail
+	return nil
+}
+
+// getConfirmationDetail returns nil if the account-block is not confirmed in store
+func getConfirmationDetail(store store.Momentum, hash types.Hash) (*AccountBlockConfirmationDetail, error) {
+	frontier, err := store.GetFrontierMomentum()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	confirmationHeight, err := store.GetBlockConfirmationHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	confirmedBlock, err := store.GetMomentumByHeight(confirmationHeight)
+	if err != nil {
+		return nil, err
 	}
 	if confirmedBlock != nil && frontier != nil && confirmedBlock.Height <= frontier.Height {
-		block.ConfirmationDetail = &AccountBlockConfirmationDetail{
+		return &AccountBlockConfirmationDetail{
 			NumConfirmations:  frontier.Height - confirmedBlock.Height + 1,
 			MomentumHeight:    confirmedBlock.Height,
 			MomentumHash:      confirmedBlock.Hash,
 			MomentumTimestamp: confirmedBlock.Timestamp.Unix(),
-		}
+		}, nil
 	}
-
-	return nil
+	return nil, nil
 }
 func (block *AccountBlock) addAllExtraInfo(chain chain.Chain) error {
 	if err := block.prefetchPaired(chain); err != nil {
@@ -294,3 +360,86 @@ func LedgerTokenInfosToRpc(list []*definition.TokenInfo) []*Token {
 
 	return tokenInfos
 }
+
+type BalanceChange struct {
+	Address       types.Address            `json:"address"`
+	TokenStandard types.ZenonTokenStandard `json:"tokenStandard"`
+	Before        *big.Int                 `json:"before"`
+	After         *big.Int                 `json:"after"`
+}
+type SimulationResult struct {
+	SendBlock        *nom.AccountBlock   `json:"sendBlock"`
+	ReceiveBlock     *nom.AccountBlock   `json:"receiveBlock"`
+	DescendantBlocks []*nom.AccountBlock `json:"descendantBlocks"`
+	BalanceChanges   []*BalanceChange    `json:"balanceChanges"`
+	BasePlasma       uint64              `json:"basePlasma"`
+	UsedPlasma       uint64              `json:"usedPlasma"`
+	EmbeddedError    *string             `json:"embeddedError"`
+}
+
+// balanceChanges returns the balances of address which are modified by patch, ordered by token-standard
+func balanceChanges(account store.Account, patch db.Patch) ([]*BalanceChange, error) {
+	before, err := account.GetBalanceMap()
+	if err != nil {
+		return nil, err
+	}
+	after := account.Snapshot()
+	if err := after.Apply(patch); err != nil {
+		return nil, err
+	}
+	afterMap, err := after.GetBalanceMap()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*BalanceChange, 0)
+	for zts, balance := range afterMap {
+		previous, ok := before[zts]
+		if !ok {
+			previous = big.NewInt(0)
+		}
+		if previous.Cmp(balance) == 0 {
+			continue
+		}
+		changes = append(changes, &BalanceChange{
+			Address:       *account.Address(),
+			TokenStandard: zts,
+			Before:        previous,
+			After:         balance,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].TokenStandard.String() < changes[j].TokenStandard.String()
+	})
+	return changes, nil
+}
+func simulationToRpc(chain chain.Chain, simulation *vm.Simulation) (*SimulationResult, error) {
+	send := simulation.SendTransaction
+	result := &SimulationResult{
+		SendBlock:        send.Block,
+		DescendantBlocks: make([]*nom.AccountBlock, 0),
+		BasePlasma:       send.Block.BasePlasma,
+		UsedPlasma:       send.Block.TotalPlasma,
+	}
+
+	changes, err := balanceChanges(chain.GetFrontierAccountStore(send.Block.Address), send.Changes)
+	if err != nil {
+		return nil, err
+	}
+	result.BalanceChanges = changes
+
+	if receive := simulation.ReceiveTransaction; receive != nil {
+		result.ReceiveBlock = receive.Block
+		result.DescendantBlocks = append(result.DescendantBlocks, receive.Block.DescendantBlocks...)
+		changes, err := balanceChanges(chain.GetFrontierAccountStore(receive.Block.Address), receive.Changes)
+		if err != nil {
+			return nil, err
+		}
+		result.BalanceChanges = append(result.BalanceChanges, changes...)
+	}
+	if simulation.ReturnedError != nil {
+		str := simulation.ReturnedError.Error()
+		result.EmbeddedError = &str
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/pow"
+	"github.com/zenon-network/go-zenon/vm/constants"
+)
+
+var (
+	ErrDifficultyParamTooBig = common.NewErrorWCode(-32000, "difficulty parameter is too big")
+	ErrPoWAlreadyRunning     = common.NewErrorWCode(-32000, "another pow is already being generated")
+)
+
+// PoWApi is an opt-in api which computes nonces on behalf of thin clients.
+// Only one nonce is computed at a time since the solver uses all available cpu-cores.
+type PoWApi struct {
+	log     log15.Logger
+	running chan struct{}
+}
+
+func NewPoWApi() *PoWApi {
+	return &PoWApi{
+		log:     common.RPCLogger.New("module", "pow_api"),
+		running: make(chan struct{}, 1),
+	}
+}
+
+type GeneratePoWParam struct {
+	Address      types.Address `json:"address"`
+	PreviousHash types.Hash    `json:"previousHash"`
+	Difficulty   uint64        `json:"difficulty"`
+}
+
+// GeneratePoW computes the nonce for an account-block template with the given address, previousHash & difficulty.
+// The computation is aborted if the client disconnects.
+func (p *PoWApi) GeneratePoW(ctx context.Context, param GeneratePoWParam) (*nom.Nonce, error) {
+	p.log.Info("GeneratePoW", "address", param.Address, "previous-hash", param.PreviousHash, "difficulty", param.Difficulty)
+	if param.Difficulty > constants.MaxDifficultyForAccountBlock {
+		return nil, ErrDifficultyParamTooBig
+	}
+
+	select {
+	case p.running <- struct{}{}:
+		defer func() { <-p.running }()
+	default:
+		return nil, ErrPoWAlreadyRunning
+	}
+
+	dataHash := pow.GetAccountBlockHash(&nom.AccountBlock{
+		Address:      param.Address,
+		PreviousHash: param.PreviousHash,
+	})
+	startTime := common.Clock.Now()
+	nonce, err := pow.Solve(ctx, dataHash, param.Difficulty)
+	if err != nil {
+		p.log.Info("GeneratePoW failed", "reason", err, "elapsed", common.Clock.Now().Sub(startTime))
+		return nil, err
+	}
+	p.log.Info("GeneratePoW finished", "elapsed", common.Clock.Now().Sub(startTime))
+	return nonce, nil
+}
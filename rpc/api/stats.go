@@ -13,6 +13,7 @@ import (
 	"github.com/zenon-network/go-zenon/p2p"
 	"github.com/zenon-network/go-zenon/p2p/discover"
 	"github.com/zenon-network/go-zenon/protocol"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
 	"github.com/zenon-network/go-zenon/zenon"
 )
 
@@ -125,3 +126,7 @@ func (api *StatsApi) NetworkInfo() (*NetworkInfoResponse, error) {
 func (api *StatsApi) SyncInfo() (*protocol.SyncInfo, error) {
 	return api.z.Broadcaster().SyncInfo(), nil
 }
+
+func (api *StatsApi) RateLimitInfo() (*rpc.RateLimitStats, error) {
+	return rpc.GetRateLimitStats(), nil
+}
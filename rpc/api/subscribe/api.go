@@ -2,7 +2,9 @@ package subscribe
 
 import (
 	"context"
+	"math/big"
 	"sync"
+	"sync/atomic"
 
 	"github.com/inconshreveable/log15"
 
@@ -11,31 +13,63 @@ import (
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
 	rpc "github.com/zenon-network/go-zenon/rpc/server"
+	"github.com/zenon-network/go-zenon/vm/embedded/events"
 )
 
 const (
-	acChanSize    = 100
-	mChanSize     = 100
+	eventChanSize = 200
 	installSize   = 100
 	uninstallSize = 100
+
+	backfillBatchSize = 100
+	// decodedSize is the number of inserted momentums whose embedded events are kept for rollbacks
+	decodedSize = 360
 )
 
 var (
 	oneSingleton sync.Mutex
 	singleton    *Server
+
+	ErrBackfillTooDeep  = common.NewErrorWCode(-32000, "fromHeight is too far behind the frontier momentum")
+	ErrTooManyBackfills = common.NewErrorWCode(-32000, "too many subscriptions are replaying stored momentums, try again later")
 )
 
+// BackfillLimits bound the work done for the subscriptions which replay stored momentums
+type BackfillLimits struct {
+	MaxDepth      uint64 // fromHeight can be at most MaxDepth momentums behind the frontier momentum
+	MaxConcurrent int    // subscriptions which replay stored momentums at the same time
+	MaxPending    int    // live events kept while replaying, the subscription is dropped if there are more
+}
+
+var DefaultBackfillLimits = BackfillLimits{
+	MaxDepth:      8640,
+	MaxConcurrent: 4,
+	MaxPending:    1000,
+}
+
 type Momentum struct {
 	Hash   types.Hash `json:"hash"`
 	Height uint64     `json:"height"`
 }
+
+func newMomentum(momentum *nom.Momentum) *Momentum {
+	return &Momentum{
+		Hash:   momentum.Hash,
+		Height: momentum.Height,
+	}
+}
+
 type AccountBlock struct {
-	BlockType uint64        `json:"blockType"`
-	Hash      types.Hash    `json:"hash"`
-	Height    uint64        `json:"height"`
-	Address   types.Address `json:"address"`
-	ToAddress types.Address `json:"toAddress"`
-	FromHash  types.Hash    `json:"fromHash"`
+	BlockType     uint64                   `json:"blockType"`
+	Hash          types.Hash               `json:"hash"`
+	Height        uint64                   `json:"height"`
+	Address       types.Address            `json:"address"`
+	ToAddress     types.Address            `json:"toAddress"`
+	FromHash      types.Hash               `json:"fromHash"`
+	TokenStandard types.ZenonTokenStandard `json:"tokenStandard"`
+	Amount        *big.Int                 `json:"amount"`
+
+	data []byte // used by filters to decode embedded methods
 }
 
 func newAccountBlock(block *nom.AccountBlock) []*AccountBlock {
@@ -47,6 +81,10 @@ func newAccountBlock(block *nom.AccountBlock) []*AccountBlock {
 		Address:   block.Address,
 		ToAddress: block.ToAddress,
 		FromHash:  block.FromBlockHash,
+
+		TokenStandard: block.TokenStandard,
+		Amount:        block.Amount,
+		data:          block.Data,
 	}
 	for _, dBlock := range block.DescendantBlocks {
 		all = append(all, newAccountBlock(dBlock)...)
@@ -54,20 +92,78 @@ func newAccountBlock(block *nom.AccountBlock) []*AccountBlock {
 	return all
 }
 
+// Rollback is notified when a momentum is removed from the chain, after the account-blocks of the momentum were notified.
+// Rollbacks are notified starting with the frontier momentum, in order with the inserted momentums.
+type Rollback struct {
+	Type          string       `json:"type"`
+	Momentum      *Momentum    `json:"momentum"`
+	AccountBlocks []types.Hash `json:"accountBlocks"`
+}
+
+const rollbackType = "rollback"
+
+// Dropped is the last notification of a subscription removed by the server, no other notifications follow
+type Dropped struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+const droppedType = "dropped"
+
+// momentumEvent is a momentum inserted in or deleted from the chain, with all its account-blocks
+type momentumEvent struct {
+	deleted  bool
+	momentum *Momentum
+	blocks   []*AccountBlock
+	detailed *nom.DetailedMomentum
+	// embeddedEvents are only decoded for inserted momentums if there are subscriptions for them,
+	// deleted momentums get the ones kept since the insert
+	embeddedEvents []*events.Event
+}
+
+func newMomentumEvent(detailed *nom.DetailedMomentum, deleted bool) *momentumEvent {
+	blocks := make([]*AccountBlock, 0, len(detailed.AccountBlocks))
+	for _, block := range detailed.AccountBlocks {
+		blocks = append(blocks, newAccountBlock(block)...)
+	}
+	return &momentumEvent{
+		deleted:  deleted,
+		momentum: newMomentum(detailed.Momentum),
+		blocks:   blocks,
+		detailed: detailed,
+	}
+}
+
+type backfillResult struct {
+	subscription *Subscription
+	// last is the last momentum replayed, or the frontier momentum if the backfill finished
+	last types.HashHeight
+	// failure is set if the stored momentums couldn't be replayed, the subscription is dropped
+	failure error
+}
+
 type Api struct {
 	chain     chain.Chain
 	log       log15.Logger
 	installCh chan *Subscription // add subscription
+	limits    BackfillLimits
+	backfills int32 // subscriptions which are installed & replaying, accessed atomically
+	// embedded events subscriptions which are installed, accessed atomically. The events are only decoded if there are any.
+	eventSubscriptions int32
 }
 type Server struct {
 	*Api
 
 	started       bool
 	uninstallCh   chan *Subscription // remove subscription
-	acCh          chan []*AccountBlock
-	mCh           chan *Momentum
+	eventCh       chan *momentumEvent
+	backfilledCh  chan *backfillResult
 	stopped       chan struct{}
 	subscriptions map[SubscriptionType]map[rpc.ID]*Subscription
+
+	// the embedded events of the last inserted momentums, by momentum hash, in insert order
+	decoded      map[types.Hash][]*events.Event
+	decodedOrder []types.Hash
 }
 
 func GetSubscribeServer(chain chain.Chain) *Server {
@@ -75,22 +171,29 @@ func GetSubscribeServer(chain chain.Chain) *Server {
 	defer oneSingleton.Unlock()
 
 	if singleton == nil {
-		singleton = &Server{
-			Api: &Api{
-				chain:     chain,
-				log:       common.RPCLogger.New("module", "subscribe_api"),
-				installCh: make(chan *Subscription, installSize),
-			},
-
-			acCh:          make(chan []*AccountBlock, acChanSize),
-			mCh:           make(chan *Momentum, mChanSize),
-			uninstallCh:   make(chan *Subscription, uninstallSize),
-			stopped:       make(chan struct{}),
-			subscriptions: make(map[SubscriptionType]map[rpc.ID]*Subscription),
-		}
+		singleton = NewSubscribeServer(chain)
 	}
 	return singleton
 }
+
+// NewSubscribeServer creates a server which isn't shared, GetSubscribeServer should be used by the node
+func NewSubscribeServer(chain chain.Chain) *Server {
+	return &Server{
+		Api: &Api{
+			chain:     chain,
+			log:       common.RPCLogger.New("module", "subscribe_api"),
+			installCh: make(chan *Subscription, installSize),
+			limits:    DefaultBackfillLimits,
+		},
+
+		eventCh:       make(chan *momentumEvent, eventChanSize),
+		backfilledCh:  make(chan *backfillResult, installSize),
+		uninstallCh:   make(chan *Subscription, uninstallSize),
+		stopped:       make(chan struct{}),
+		subscriptions: make(map[SubscriptionType]map[rpc.ID]*Subscription),
+		decoded:       make(map[types.Hash][]*events.Event),
+	}
+}
 func GetSubscribeApi() *Api {
 	oneSingleton.Lock()
 	defer oneSingleton.Unlock()
@@ -103,6 +206,11 @@ func GetSubscribeApi() *Api {
 	return singleton.Api
 }
 
+// SetBackfillLimits replaces DefaultBackfillLimits, it must be called before Start
+func (s *Server) SetBackfillLimits(limits BackfillLimits) {
+	s.limits = limits
+}
+
 func (s *Server) Init() error {
 	s.log.Info("init")
 	defer s.log.Info("finish init")
@@ -129,27 +237,23 @@ func (s *Server) Stop() error {
 }
 
 func (s *Server) InsertMomentum(detailed *nom.DetailedMomentum) {
+	event := newMomentumEvent(detailed, false)
+	if atomic.LoadInt32(&s.eventSubscriptions) != 0 {
+		// decoded while the momentum can't be rolled back, the events are kept for the rollback notifications
+		event.embeddedEvents = s.decodeEvents(event)
+	}
 	select {
-	case s.mCh <- &Momentum{
-		Hash:   detailed.Momentum.Hash,
-		Height: detailed.Momentum.Height,
-	}:
+	case s.eventCh <- event:
 	default:
 		s.log.Error("can't insert momentum for broadcast", "reason", "channel is full", "momentum-identifier", detailed.Momentum.Identifier())
 	}
-
-	abEvents := make([]*AccountBlock, 0, len(detailed.AccountBlocks))
-	for _, block := range detailed.AccountBlocks {
-		abEvents = append(abEvents, newAccountBlock(block)...)
-	}
+}
+func (s *Server) DeleteMomentum(detailed *nom.DetailedMomentum) {
 	select {
-	case s.acCh <- abEvents:
+	case s.eventCh <- newMomentumEvent(detailed, true):
 	default:
-		s.log.Error("can't insert account-blocks for broadcast", "reason", "channel is full", "momentum-identifier", detailed.Momentum.Identifier())
+		s.log.Error("can't delete momentum for broadcast", "reason", "channel is full", "momentum-identifier", detailed.Momentum.Identifier())
 	}
-	return
-}
-func (s *Server) DeleteMomentum(*nom.DetailedMomentum) {
 }
 
 func (s *Server) work() {
@@ -167,10 +271,21 @@ func (s *Server) work() {
 			s.install(sub)
 		case sub := <-s.uninstallCh:
 			s.uninstall(sub)
-		case momentums := <-s.mCh:
-			s.broadcastMomentums(momentums)
-		case blocks := <-s.acCh:
-			s.broadcastBlocks(blocks)
+		case result := <-s.backfilledCh:
+			s.finishBackfill(result)
+		case event := <-s.eventCh:
+			if event.deleted {
+				event.embeddedEvents = s.decoded[event.momentum.Hash]
+				delete(s.decoded, event.momentum.Hash)
+			}
+			s.queuePending(event)
+			if event.deleted {
+				s.broadcastRollback(event)
+			} else {
+				s.broadcastMomentums(event.momentum)
+				s.broadcastBlocks(event)
+				s.broadcastEmbeddedEvents(event)
+			}
 		}
 	}
 }
@@ -183,19 +298,191 @@ type BroadcastStats struct {
 func (s *Server) install(subscription *Subscription) {
 	s.log.Info("install", "id", subscription.rpc.ID)
 	s.subscriptions[subscription.options.subscriptionType][subscription.rpc.ID] = subscription
+	if subscription.options.fromHeight != 0 {
+		// live events are kept until the backfill reaches the frontier momentum read after the install
+		subscription.backfilling = true
+		go s.backfill(subscription)
+	}
 }
 func (s *Server) uninstall(subscription *Subscription) {
 	s.log.Info("uninstall", "id", subscription.rpc.ID)
-	delete(s.subscriptions[subscription.options.subscriptionType], subscription.rpc.ID)
+	subscriptions := s.subscriptions[subscription.options.subscriptionType]
+	if _, ok := subscriptions[subscription.rpc.ID]; ok && subscription.options.subscriptionType == EmbeddedEventsSubscription {
+		atomic.AddInt32(&s.eventSubscriptions, -1)
+	}
+	delete(subscriptions, subscription.rpc.ID)
+}
+
+// queuePending keeps the event for the subscriptions which are backfilling, the ones with too many pending events are dropped
+func (s *Server) queuePending(event *momentumEvent) {
+	for _, subscriptions := range s.subscriptions {
+		for _, subscription := range subscriptions {
+			if !subscription.backfilling {
+				continue
+			}
+			if len(subscription.pending) >= s.limits.MaxPending {
+				s.log.Info("dropping subscription", "id", subscription.rpc.ID, "reason", "too many pending events")
+				atomic.StoreInt32(&subscription.dropped, 1)
+				subscription.pending = nil
+				s.uninstall(subscription)
+				continue
+			}
+			subscription.pending = append(subscription.pending, event)
+		}
+	}
 }
-func (s *Server) broadcast(subscription *Subscription, data interface{}, stats *BroadcastStats) {
+func (s *Server) broadcast(subscription *Subscription, n *notification, stats *BroadcastStats) {
+	if subscription.backfilling {
+		// queued by queuePending
+		return
+	}
+	if n.rollback {
+		// momentums at this height will be inserted again
+		if n.height < subscription.nextHeight {
+			subscription.nextHeight = n.height
+		}
+		if n.data == nil {
+			return
+		}
+	} else if n.height < subscription.nextHeight {
+		// already notified by the backfill
+		return
+	}
 	if subscription.Closed() {
 		stats.NumUninstalls += 1
 		s.uninstall(subscription)
 	} else {
+		stats.NumNotify += 1
+		subscription.Notify(n.data)
+		if !n.rollback {
+			subscription.nextHeight = n.height + 1
+		}
+	}
+}
+
+// backfill notifies the stored momentums starting at fromHeight up to the frontier momentum
+func (s *Server) backfill(subscription *Subscription) {
+	defer common.RecoverStack()
+	log := subscription.log.New("from-height", subscription.options.fromHeight)
+	store := s.chain.GetFrontierMomentumStore()
+	nextHeight := subscription.options.fromHeight
+	var last types.HashHeight
+	var failure error
+	defer func() {
+		select {
+		case s.backfilledCh <- &backfillResult{subscription: subscription, last: last, failure: failure}:
+		case <-s.stopped:
+		}
+	}()
+
+	frontier, err := store.GetFrontierMomentum()
+	if err != nil {
+		log.Error("failed to backfill", "reason", err)
+		failure = err
+		return
+	}
+	for nextHeight <= frontier.Height {
+		if subscription.Closed() || atomic.LoadInt32(&subscription.dropped) == 1 {
+			return
+		}
+		size := uint64(backfillBatchSize)
+		if nextHeight+size > frontier.Height+1 {
+			size = frontier.Height + 1 - nextHeight
+		}
+		momentums, err := store.GetMomentumsByHeight(nextHeight, true, size)
+		if err != nil {
+			log.Error("failed to backfill", "reason", err)
+			failure = err
+			return
+		}
+		for _, momentum := range momentums {
+			detailed, err := store.PrefetchMomentum(momentum)
+			if err != nil {
+				log.Error("failed to backfill", "reason", err)
+				failure = err
+				return
+			}
+			event := newMomentumEvent(detailed, false)
+			if subscription.options.subscriptionType == EmbeddedEventsSubscription {
+				if event.embeddedEvents, err = events.Decode(store, detailed); err != nil {
+					log.Error("failed to backfill", "reason", err)
+					failure = err
+					return
+				}
+			}
+			if data := subscription.options.notification(event); data != nil {
+				subscription.Notify(data)
+			}
+			nextHeight = momentum.Height + 1
+			last = momentum.Identifier()
+		}
+	}
+	last = frontier.Identifier()
+	log.Info("finish backfill", "frontier-identifier", frontier.Identifier())
+}
+
+// finishBackfill notifies the pending events which follow the last replayed momentum. The pending events may
+// predate the store read by the backfill, so only the inserts & rollbacks which continue the replayed chain are notified.
+func (s *Server) finishBackfill(result *backfillResult) {
+	atomic.AddInt32(&s.backfills, -1)
+	subscription := result.subscription
+	subscription.backfilling = false
+	pending := subscription.pending
+	subscription.pending = nil
+	if atomic.LoadInt32(&subscription.dropped) == 1 {
+		subscription.Notify(&Dropped{
+			Type:   droppedType,
+			Reason: "too many events while replaying stored momentums",
+		})
+		return
+	}
+	if result.failure != nil {
+		// the live events can't follow the replayed ones without a gap
+		s.log.Info("dropping subscription", "id", subscription.rpc.ID, "reason", result.failure)
+		s.uninstall(subscription)
+		subscription.Notify(&Dropped{
+			Type:   droppedType,
+			Reason: "failed to replay stored momentums",
+		})
+		return
+	}
+
+	stats := &BroadcastStats{}
+	last := result.last
+	for _, event := range pending {
+		identifier := types.HashHeight{Hash: event.momentum.Hash, Height: event.momentum.Height}
+		var data interface{}
+		if event.deleted {
+			if !last.IsZero() && identifier != last {
+				continue
+			}
+			last = types.HashHeight{Hash: event.detailed.Momentum.PreviousHash, Height: identifier.Height - 1}
+			if rollback := subscription.options.rollback(event); rollback != nil {
+				data = rollback
+			}
+		} else {
+			if !last.IsZero() && (identifier.Height != last.Height+1 || event.detailed.Momentum.PreviousHash != last.Hash) {
+				continue
+			}
+			last = identifier
+			data = subscription.options.notification(event)
+		}
+		if data == nil || identifier.Height < subscription.options.fromHeight {
+			continue
+		}
+		if subscription.Closed() {
+			stats.NumUninstalls += 1
+			s.uninstall(subscription)
+			break
+		}
 		stats.NumNotify += 1
 		subscription.Notify(data)
 	}
+	subscription.nextHeight = last.Height + 1
+	if subscription.nextHeight < subscription.options.fromHeight {
+		subscription.nextHeight = subscription.options.fromHeight
+	}
+	s.log.Info("finish broadcasting pending events", "id", subscription.rpc.ID, "stats", stats)
 }
 func (s *Server) broadcastMomentums(momentum *Momentum) {
 	if momentum == nil {
@@ -205,15 +492,17 @@ func (s *Server) broadcastMomentums(momentum *Momentum) {
 	stats := &BroadcastStats{}
 
 	for _, f := range s.subscriptions[MomentumsSubscription] {
-		s.broadcast(f, []interface{}{momentum}, stats)
+		s.broadcast(f, &notification{height: momentum.Height, data: []interface{}{momentum}}, stats)
 	}
 
 	s.log.Info("finish broadcasting momentum", "identifier", momentum, "elapsed", common.Clock.Now().Sub(startTime), "stats", stats)
 }
-func (s *Server) broadcastBlocks(blocks []*AccountBlock) {
-	if len(blocks) == 0 {
+func (s *Server) broadcastBlocks(event *momentumEvent) {
+	if event == nil || len(event.blocks) == 0 {
 		return
 	}
+	height := event.momentum.Height
+	blocks := event.blocks
 	startTime := common.Clock.Now()
 	stats := &BroadcastStats{}
 
@@ -233,45 +522,161 @@ func (s *Server) broadcastBlocks(blocks []*AccountBlock) {
 	}
 
 	for _, f := range s.subscriptions[AllAccountBlocksSubscription] {
-		s.broadcast(f, blocks, stats)
+		s.broadcast(f, &notification{height: height, data: blocks}, stats)
 	}
 	for _, f := range s.subscriptions[AccountBlocksSubscriptionByAddress] {
 		if blocks, ok := byAddress[f.options.address]; ok {
-			s.broadcast(f, blocks, stats)
+			s.broadcast(f, &notification{height: height, data: blocks}, stats)
 		}
 	}
 	for _, f := range s.subscriptions[UnreceivedAccountBlocksSubscriptionByAddress] {
 		if blocks, ok := unreceivedByAddress[f.options.address]; ok {
-			s.broadcast(f, blocks, stats)
+			s.broadcast(f, &notification{height: height, data: blocks}, stats)
+		}
+	}
+	for _, f := range s.subscriptions[AccountBlocksSubscriptionByFilter] {
+		if blocks := f.options.notification(event); blocks != nil {
+			s.broadcast(f, &notification{height: height, data: blocks}, stats)
 		}
 	}
 
 	s.log.Info("finish broadcasting account-blocks", "elapsed", common.Clock.Now().Sub(startTime), "stats", stats)
 }
 
-func (s *Api) subscribe(ctx context.Context, options *subscriptionOptions) (*rpc.Subscription, error) {
+func (s *Server) broadcastEmbeddedEvents(event *momentumEvent) {
+	if len(s.subscriptions[EmbeddedEventsSubscription]) == 0 {
+		return
+	}
+	startTime := common.Clock.Now()
+	stats := &BroadcastStats{}
+
+	identifier := types.HashHeight{Hash: event.momentum.Hash, Height: event.momentum.Height}
+	if event.embeddedEvents == nil {
+		// the first subscription was installed after the momentum was inserted
+		if event.embeddedEvents = s.decodeEvents(event); event.embeddedEvents == nil {
+			return
+		}
+	}
+	s.keepDecoded(event.momentum.Hash, event.embeddedEvents)
+
+	for _, f := range s.subscriptions[EmbeddedEventsSubscription] {
+		if data := f.options.notification(event); data != nil {
+			s.broadcast(f, &notification{height: event.momentum.Height, data: data}, stats)
+		}
+	}
+
+	s.log.Info("finish broadcasting embedded events", "identifier", identifier, "elapsed", common.Clock.Now().Sub(startTime), "stats", stats)
+}
+
+// decodeEvents returns the embedded events of the inserted momentum, or nil if the momentum was rolled back since
+func (s *Server) decodeEvents(event *momentumEvent) []*events.Event {
+	identifier := types.HashHeight{Hash: event.momentum.Hash, Height: event.momentum.Height}
+	store := s.chain.GetMomentumStore(identifier)
+	if store == nil {
+		// the subscriptions will be notified about the rollback
+		s.log.Info("skip decoding embedded events", "identifier", identifier, "reason", "momentum is missing")
+		return nil
+	}
+	decoded, err := events.Decode(store, event.detailed)
+	if err != nil {
+		s.log.Error("can't decode embedded events for broadcast", "reason", err, "identifier", identifier)
+		return nil
+	}
+	return decoded
+}
+
+// keepDecoded keeps the embedded events of the last decodedSize inserted momentums, so rollbacks can be filtered like the inserts
+func (s *Server) keepDecoded(hash types.Hash, decoded []*events.Event) {
+	s.decoded[hash] = decoded
+	s.decodedOrder = append(s.decodedOrder, hash)
+	if len(s.decodedOrder) > decodedSize {
+		delete(s.decoded, s.decodedOrder[0])
+		s.decodedOrder = s.decodedOrder[1:]
+	}
+}
+
+// broadcastRollback notifies every subscription, even the ones without matching account-blocks need to know
+// that the momentum height can be inserted again
+func (s *Server) broadcastRollback(event *momentumEvent) {
+	startTime := common.Clock.Now()
+	stats := &BroadcastStats{}
+
+	for _, subscriptions := range s.subscriptions {
+		for _, f := range subscriptions {
+			n := &notification{height: event.momentum.Height, rollback: true}
+			if rollback := f.options.rollback(event); rollback != nil {
+				n.data = rollback
+			}
+			s.broadcast(f, n, stats)
+		}
+	}
+
+	s.log.Info("finish broadcasting rollback", "identifier", event.momentum, "elapsed", common.Clock.Now().Sub(startTime), "stats", stats)
+}
+
+// subscribe installs the subscription. If fromHeight is set, the stored momentums starting at fromHeight are notified first,
+// followed by the live events, without gaps or duplicates. The backfills are bounded by the BackfillLimits of the server.
+func (s *Api) subscribe(ctx context.Context, options *subscriptionOptions, fromHeight *uint64) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return nil, rpc.ErrNotificationsUnsupported
 	}
+	if fromHeight != nil {
+		options.fromHeight = *fromHeight
+		if options.fromHeight == 0 {
+			options.fromHeight = 1
+		}
+		frontier, err := s.chain.GetFrontierMomentumStore().GetFrontierMomentum()
+		if err != nil {
+			return nil, err
+		}
+		if frontier.Height > options.fromHeight && frontier.Height-options.fromHeight > s.limits.MaxDepth {
+			return nil, ErrBackfillTooDeep
+		}
+		// released by the worker once the backfill is finished
+		if atomic.AddInt32(&s.backfills, 1) > int32(s.limits.MaxConcurrent) {
+			atomic.AddInt32(&s.backfills, -1)
+			return nil, ErrTooManyBackfills
+		}
+	}
 	subscription := NewSubscription(notifier, options)
+	if options.subscriptionType == EmbeddedEventsSubscription {
+		// released by the worker once the subscription is uninstalled
+		atomic.AddInt32(&s.eventSubscriptions, 1)
+	}
 	s.installCh <- subscription
 	return subscription.rpc, nil
 }
 
-func (s *Api) Momentums(ctx context.Context) (*rpc.Subscription, error) {
+func (s *Api) Momentums(ctx context.Context, fromHeight *uint64) (*rpc.Subscription, error) {
 	s.log.Info("new subscription", "type", "Momentums")
-	return s.subscribe(ctx, NewMomentumsSubscription())
+	return s.subscribe(ctx, NewMomentumsSubscription(), fromHeight)
 }
-func (s *Api) AllAccountBlocks(ctx context.Context) (*rpc.Subscription, error) {
+func (s *Api) AllAccountBlocks(ctx context.Context, fromHeight *uint64) (*rpc.Subscription, error) {
 	s.log.Info("new subscription", "type", "AllAccountBlocks")
-	return s.subscribe(ctx, NewBlocksSubscription())
+	return s.subscribe(ctx, NewBlocksSubscription(), fromHeight)
 }
-func (s *Api) AccountBlocksByAddress(ctx context.Context, address types.Address) (*rpc.Subscription, error) {
+func (s *Api) AccountBlocksByAddress(ctx context.Context, address types.Address, fromHeight *uint64) (*rpc.Subscription, error) {
 	s.log.Info("new subscription", "type", "AccountBlocksByAddress")
-	return s.subscribe(ctx, NewBlocksByAddressSubscription(address))
+	return s.subscribe(ctx, NewBlocksByAddressSubscription(address), fromHeight)
 }
-func (s *Api) UnreceivedAccountBlocksByAddress(ctx context.Context, address types.Address) (*rpc.Subscription, error) {
+func (s *Api) UnreceivedAccountBlocksByAddress(ctx context.Context, address types.Address, fromHeight *uint64) (*rpc.Subscription, error) {
 	s.log.Info("new subscription", "type", "UnreceivedAccountBlocksByAddress")
-	return s.subscribe(ctx, NewToUnreceivedBlocksSubscription(address))
+	return s.subscribe(ctx, NewToUnreceivedBlocksSubscription(address), fromHeight)
+}
+func (s *Api) AccountBlocksByFilter(ctx context.Context, filter *AccountBlockFilter, fromHeight *uint64) (*rpc.Subscription, error) {
+	s.log.Info("new subscription", "type", "AccountBlocksByFilter")
+	options, err := NewBlocksByFilterSubscription(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.subscribe(ctx, options, fromHeight)
+}
+func (s *Api) EmbeddedEvents(ctx context.Context, filter *events.Filter, fromHeight *uint64) (*rpc.Subscription, error) {
+	s.log.Info("new subscription", "type", "EmbeddedEvents")
+	options, err := NewEmbeddedEventsSubscription(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.subscribe(ctx, options, fromHeight)
 }
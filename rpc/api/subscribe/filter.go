@@ -0,0 +1,133 @@
+package subscribe
+
+import (
+	"math/big"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/embedded"
+)
+
+var (
+	ErrFilterNotEmbeddedContract = common.NewErrorWCode(-32000, "filter embedded contract is not an embedded address")
+	ErrFilterUnknownMethod       = common.NewErrorWCode(-32000, "filter method is not a method of any embedded contract")
+)
+
+// AccountBlockFilter selects the account-blocks which match all the set fields, an empty list matches any value.
+// EmbeddedContracts & Methods match the blocks sent to embedded contracts, the method is decoded from the block data.
+type AccountBlockFilter struct {
+	Addresses         []types.Address            `json:"addresses"`
+	ToAddresses       []types.Address            `json:"toAddresses"`
+	TokenStandards    []types.ZenonTokenStandard `json:"tokenStandards"`
+	BlockTypes        []uint64                   `json:"blockTypes"`
+	MinAmount         *big.Int                   `json:"minAmount"`
+	EmbeddedContracts []types.Address            `json:"embeddedContracts"`
+	Methods           []string                   `json:"methods"`
+}
+
+type accountBlockFilter struct {
+	addresses         map[types.Address]bool
+	toAddresses       map[types.Address]bool
+	tokenStandards    map[types.ZenonTokenStandard]bool
+	blockTypes        map[uint64]bool
+	minAmount         *big.Int
+	embeddedContracts map[types.Address]bool
+	methods           map[string]bool
+}
+
+func newAccountBlockFilter(filter *AccountBlockFilter) (*accountBlockFilter, error) {
+	f := &accountBlockFilter{
+		addresses:         make(map[types.Address]bool),
+		toAddresses:       make(map[types.Address]bool),
+		tokenStandards:    make(map[types.ZenonTokenStandard]bool),
+		blockTypes:        make(map[uint64]bool),
+		embeddedContracts: make(map[types.Address]bool),
+		methods:           make(map[string]bool),
+	}
+	if filter == nil {
+		return f, nil
+	}
+	for _, address := range filter.Addresses {
+		f.addresses[address] = true
+	}
+	for _, address := range filter.ToAddresses {
+		f.toAddresses[address] = true
+	}
+	for _, zts := range filter.TokenStandards {
+		f.tokenStandards[zts] = true
+	}
+	for _, blockType := range filter.BlockTypes {
+		f.blockTypes[blockType] = true
+	}
+	if filter.MinAmount != nil {
+		f.minAmount = new(big.Int).Set(filter.MinAmount)
+	}
+	for _, address := range filter.EmbeddedContracts {
+		if !types.IsEmbeddedAddress(address) {
+			return nil, ErrFilterNotEmbeddedContract
+		}
+		f.embeddedContracts[address] = true
+	}
+	for _, method := range filter.Methods {
+		if !embedded.IsEmbeddedMethodName(method) {
+			return nil, ErrFilterUnknownMethod
+		}
+		f.methods[method] = true
+	}
+	return f, nil
+}
+
+// AccountBlockMatcher matches account-blocks with an AccountBlockFilter outside of subscriptions
+type AccountBlockMatcher struct {
+	filter *accountBlockFilter
+}
+
+func NewAccountBlockMatcher(filter *AccountBlockFilter) (*AccountBlockMatcher, error) {
+	f, err := newAccountBlockFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountBlockMatcher{filter: f}, nil
+}
+
+// Match returns the account-blocks of detailed, including the descendant blocks, which match the filter
+func (m *AccountBlockMatcher) Match(detailed *nom.DetailedMomentum) []*AccountBlock {
+	matching := make([]*AccountBlock, 0)
+	for _, block := range detailed.AccountBlocks {
+		for _, event := range newAccountBlock(block) {
+			if m.filter.matches(event) {
+				matching = append(matching, event)
+			}
+		}
+	}
+	return matching
+}
+
+func (f *accountBlockFilter) matches(block *AccountBlock) bool {
+	if len(f.addresses) != 0 && !f.addresses[block.Address] {
+		return false
+	}
+	if len(f.toAddresses) != 0 && !f.toAddresses[block.ToAddress] {
+		return false
+	}
+	if len(f.tokenStandards) != 0 && !f.tokenStandards[block.TokenStandard] {
+		return false
+	}
+	if len(f.blockTypes) != 0 && !f.blockTypes[block.BlockType] {
+		return false
+	}
+	if f.minAmount != nil && (block.Amount == nil || block.Amount.Cmp(f.minAmount) < 0) {
+		return false
+	}
+	if len(f.embeddedContracts) != 0 && !f.embeddedContracts[block.ToAddress] {
+		return false
+	}
+	if len(f.methods) != 0 {
+		name, err := embedded.GetEmbeddedMethodName(block.ToAddress, block.data)
+		if err != nil || !f.methods[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -6,8 +6,10 @@ import (
 	"github.com/inconshreveable/log15"
 	rpc "github.com/zenon-network/go-zenon/rpc/server"
 
+	"github.com/zenon-network/go-zenon/chain/nom"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/embedded/events"
 )
 
 type SubscriptionType byte
@@ -18,6 +20,8 @@ const (
 	AccountBlocksSubscriptionByAddress
 	UnreceivedAccountBlocksSubscriptionByAddress
 	MomentumsSubscription
+	AccountBlocksSubscriptionByFilter
+	EmbeddedEventsSubscription
 	LastSubscriptionType
 )
 
@@ -25,6 +29,10 @@ type subscriptionOptions struct {
 	subscriptionType SubscriptionType
 	createTime       time.Time
 	address          types.Address
+	filter           *accountBlockFilter
+	eventFilter      *events.Filter
+	// fromHeight is the height of the first momentum replayed from the store before the live events, 0 for live events only
+	fromHeight uint64
 }
 
 func newSubscription(subscriptionType SubscriptionType) *subscriptionOptions {
@@ -49,12 +57,122 @@ func NewToUnreceivedBlocksSubscription(addr types.Address) *subscriptionOptions
 func NewMomentumsSubscription() *subscriptionOptions {
 	return newSubscription(MomentumsSubscription)
 }
+func NewBlocksByFilterSubscription(filter *AccountBlockFilter) (*subscriptionOptions, error) {
+	f, err := newAccountBlockFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	sub := newSubscription(AccountBlocksSubscriptionByFilter)
+	sub.filter = f
+	return sub, nil
+}
+func NewEmbeddedEventsSubscription(filter *events.Filter) (*subscriptionOptions, error) {
+	if err := filter.Check(); err != nil {
+		return nil, err
+	}
+	sub := newSubscription(EmbeddedEventsSubscription)
+	sub.eventFilter = filter
+	return sub, nil
+}
+
+// notification returns the data notified to the subscription for an inserted momentum, or nil if nothing matches
+func (o *subscriptionOptions) notification(event *momentumEvent) interface{} {
+	if o.subscriptionType == MomentumsSubscription {
+		return []interface{}{event.momentum}
+	}
+	if o.subscriptionType == EmbeddedEventsSubscription {
+		matching := make([]*events.Event, 0)
+		for _, embeddedEvent := range event.embeddedEvents {
+			if o.eventFilter.Matches(embeddedEvent) {
+				matching = append(matching, embeddedEvent)
+			}
+		}
+		if len(matching) == 0 {
+			return nil
+		}
+		return matching
+	}
+	blocks := make([]*AccountBlock, 0)
+	for _, block := range event.blocks {
+		if o.matches(block) {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	return blocks
+}
+
+// rollback returns the data notified to the subscription for a deleted momentum, or nil if nothing matches.
+// The embedded events subscriptions receive the contract-receive blocks of the matching events decoded when the momentum
+// was inserted. If they weren't kept, the events can't be decoded anymore, so the blocks are only matched by contract.
+func (o *subscriptionOptions) rollback(event *momentumEvent) *Rollback {
+	hashes := make([]types.Hash, 0)
+	if o.subscriptionType == EmbeddedEventsSubscription {
+		if event.embeddedEvents != nil {
+			for _, embeddedEvent := range event.embeddedEvents {
+				if o.eventFilter.Matches(embeddedEvent) {
+					hashes = append(hashes, embeddedEvent.ReceiveBlockHash)
+				}
+			}
+		} else {
+			for _, block := range event.detailed.AccountBlocks {
+				if events.IsEventBlock(block) && o.eventFilter.MatchesContract(block.Address) {
+					hashes = append(hashes, block.Hash)
+				}
+			}
+		}
+	} else {
+		for _, block := range event.blocks {
+			if o.subscriptionType == MomentumsSubscription || o.matches(block) {
+				hashes = append(hashes, block.Hash)
+			}
+		}
+	}
+	if len(hashes) == 0 && o.subscriptionType != MomentumsSubscription {
+		return nil
+	}
+	return &Rollback{
+		Type:          rollbackType,
+		Momentum:      event.momentum,
+		AccountBlocks: hashes,
+	}
+}
+func (o *subscriptionOptions) matches(block *AccountBlock) bool {
+	switch o.subscriptionType {
+	case AllAccountBlocksSubscription:
+		return true
+	case AccountBlocksSubscriptionByAddress:
+		return block.Address == o.address
+	case UnreceivedAccountBlocksSubscriptionByAddress:
+		return nom.IsSendBlock(block.BlockType) && block.ToAddress == o.address
+	case AccountBlocksSubscriptionByFilter:
+		return o.filter.matches(block)
+	default:
+		return false
+	}
+}
+
+type notification struct {
+	height   uint64
+	data     interface{}
+	rollback bool
+}
 
 type Subscription struct {
 	log      log15.Logger
 	options  *subscriptionOptions
 	notifier *rpc.Notifier
 	rpc      *rpc.Subscription
+
+	// only used by the worker of the server
+	backfilling bool
+	pending     []*momentumEvent // live events received while backfilling
+	nextHeight  uint64           // events of lower momentums were already notified
+
+	// dropped is set by the worker if there are too many pending events, it stops the backfill. Accessed atomically.
+	dropped int32
 }
 
 func NewSubscription(notifier *rpc.Notifier, options *subscriptionOptions) *Subscription {
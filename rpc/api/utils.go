@@ -5,8 +5,10 @@ import (
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/vm/vm_context"
+	"github.com/zenon-network/go-zenon/zenon"
 )
 
 const (
@@ -42,6 +44,65 @@ func GetFrontierContext(c chain.Chain, addr types.Address) (*nom.Momentum, vm_co
 	return frontier, context, nil
 }
 
+// GetContext is the same as GetFrontierContext if momentumHeight is nil.
+// Otherwise, the context only contains the state confirmed by the momentum at momentumHeight.
+func GetContext(z zenon.Zenon, addr types.Address, momentumHeight *uint64) (*nom.Momentum, vm_context.AccountVmContext, error) {
+	if momentumHeight == nil {
+		return GetFrontierContext(z.Chain(), addr)
+	}
+
+	store, err := GetMomentumStoreByHeight(z, *momentumHeight)
+	if err != nil {
+		return nil, nil, err
+	}
+	momentum, err := store.GetFrontierMomentum()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	context := vm_context.NewAccountContext(
+		store,
+		store.GetAccountStore(addr),
+		nil,
+	)
+	return momentum, context, nil
+}
+
+// GetMomentumStoreByHeight opens the versioned momentum-store at momentumHeight.
+// Returns ErrStateNotAvailable if momentumHeight is more than the history depth of the node behind the frontier,
+// or if the node no longer has the patches required to rebuild it.
+func GetMomentumStoreByHeight(z zenon.Zenon, momentumHeight uint64) (store.Momentum, error) {
+	if momentumHeight == 0 {
+		return nil, ErrHeightParamIsZero
+	}
+	c := z.Chain()
+	frontierStore := c.GetFrontierMomentumStore()
+	frontier, err := frontierStore.GetFrontierMomentum()
+	if err != nil {
+		return nil, err
+	}
+	if momentumHeight > frontier.Height {
+		return nil, ErrMomentumHeightTooBig
+	}
+	// every momentum between momentumHeight & the frontier is rolled back to rebuild the state
+	if frontier.Height-momentumHeight > z.Config().HistoryDepth() {
+		return nil, ErrStateNotAvailable
+	}
+	momentum, err := frontierStore.GetMomentumByHeight(momentumHeight)
+	if err != nil {
+		return nil, err
+	}
+	if momentum == nil {
+		return nil, ErrMomentumHeightTooBig
+	}
+
+	store := c.GetMomentumStore(momentum.Identifier())
+	if store == nil {
+		return nil, ErrStateNotAvailable
+	}
+	return store, nil
+}
+
 func checkTokenIdValid(chain chain.Chain, ts *types.ZenonTokenStandard) error {
 	store := chain.GetFrontierMomentumStore()
 	if ts != nil && (*ts) != types.ZeroTokenStandard {
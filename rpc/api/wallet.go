@@ -0,0 +1,201 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/wallet"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+const (
+	walletMaxAddressCount       = wallet.DefaultMaxIndex
+	walletDefaultUnlockDuration = 300 // seconds
+)
+
+// WalletApi manages the keyFiles stored in the wallet directory of the node & signs blocks with them.
+// It is not public and must only be served over IPC or authenticated endpoints.
+type WalletApi struct {
+	z       zenon.Zenon
+	chain   chain.Chain
+	manager *wallet.Manager
+	log     log15.Logger
+}
+
+func NewWalletApi(z zenon.Zenon, manager *wallet.Manager) *WalletApi {
+	return &WalletApi{
+		z:       z,
+		chain:   z.Chain(),
+		manager: manager,
+		log:     common.RPCLogger.New("module", "wallet_api"),
+	}
+}
+
+type KeyFileInfo struct {
+	Path        string        `json:"path"`
+	BaseAddress types.Address `json:"baseAddress"`
+	Unlocked    bool          `json:"unlocked"`
+}
+type DerivedAddress struct {
+	Index   uint32        `json:"index"`
+	Address types.Address `json:"address"`
+}
+
+func (w *WalletApi) keyFileInfo(kf *wallet.KeyFile) (*KeyFileInfo, error) {
+	unlocked, err := w.manager.IsUnlocked(kf.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyFileInfo{
+		Path:        kf.Path,
+		BaseAddress: kf.BaseAddress,
+		Unlocked:    unlocked,
+	}, nil
+}
+
+func (w *WalletApi) ListKeyFiles() ([]*KeyFileInfo, error) {
+	files := w.manager.ListKeyFiles()
+	result := make([]*KeyFileInfo, 0, len(files))
+	for _, kf := range files {
+		info, err := w.keyFileInfo(kf)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// CreateKeyFile generates a new random seed & stores it in the wallet directory, encrypted with password
+func (w *WalletApi) CreateKeyFile(name, password string) (*KeyFileInfo, error) {
+	kf, err := w.manager.CreateKeyFile(name, password)
+	if err != nil {
+		return nil, err
+	}
+	w.log.Info("created keyFile", "path", kf.Path, "base-address", kf.BaseAddress)
+	return w.keyFileInfo(kf)
+}
+
+// ImportKeyFile copies the content of an existing keyFile in the wallet directory.
+// The password is only used to check that the keyFile can be decrypted, the keyFile remains locked.
+func (w *WalletApi) ImportKeyFile(name string, keyFile json.RawMessage, password string) (*KeyFileInfo, error) {
+	kf, err := w.manager.ImportKeyFile(name, keyFile, password)
+	if err != nil {
+		return nil, err
+	}
+	w.log.Info("imported keyFile", "path", kf.Path, "base-address", kf.BaseAddress)
+	return w.keyFileInfo(kf)
+}
+
+// ImportMnemonic stores the seed of a BIP39 mnemonic, and of the optional passphrase, in the wallet directory, encrypted with password
+func (w *WalletApi) ImportMnemonic(name, mnemonic, password string, passphrase *string) (*KeyFileInfo, error) {
+	bip39Passphrase := ""
+	if passphrase != nil {
+		bip39Passphrase = *passphrase
+	}
+	kf, err := w.manager.ImportMnemonic(name, mnemonic, bip39Passphrase, password)
+	if err != nil {
+		return nil, err
+	}
+	w.log.Info("imported mnemonic", "path", kf.Path, "base-address", kf.BaseAddress)
+	return w.keyFileInfo(kf)
+}
+
+// ExportMnemonic returns the BIP39 mnemonic of a keyFile. The password is required even if the keyFile is unlocked.
+func (w *WalletApi) ExportMnemonic(path, password string) (string, error) {
+	ks, err := w.manager.GetKeyFileAndDecrypt(path, password)
+	if err != nil {
+		return "", err
+	}
+	defer ks.Zero()
+	w.log.Info("exported mnemonic", "path", path)
+	return ks.ExportMnemonic()
+}
+
+// GetAddresses derives count addresses, starting with index, from an unlocked keyFile
+func (w *WalletApi) GetAddresses(path string, index, count uint32) ([]*DerivedAddress, error) {
+	if count > walletMaxAddressCount {
+		return nil, ErrCountParamTooBig
+	}
+	keyPairs, err := w.manager.DeriveKeyPairs(path, index, count)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*DerivedAddress, len(keyPairs))
+	for i, kp := range keyPairs {
+		result[i] = &DerivedAddress{
+			Index:   index + uint32(i),
+			Address: kp.Address,
+		}
+	}
+	return result, nil
+}
+
+// Unlock decrypts the keyFile for duration seconds. If duration is missing, the keyFile is unlocked for 5 minutes.
+// A duration of 0 keeps the keyFile unlocked until Lock is called.
+func (w *WalletApi) Unlock(path, password string, duration *uint64) error {
+	seconds := uint64(walletDefaultUnlockDuration)
+	if duration != nil {
+		seconds = *duration
+	}
+	if err := w.manager.UnlockWithTimeout(path, password, time.Duration(seconds)*time.Second); err != nil {
+		return err
+	}
+	w.log.Info("unlocked keyFile", "path", path, "duration", seconds)
+	return nil
+}
+func (w *WalletApi) Lock(path string) error {
+	if _, err := w.manager.IsUnlocked(path); err != nil {
+		return err
+	}
+	w.manager.Lock(path)
+	w.log.Info("locked keyFile", "path", path)
+	return nil
+}
+
+// SignAndPublish fills in the missing fields of a user block template, signs it with the key of template.Address
+// found in the unlocked keyFile & publishes it. The template must either have enough fused plasma or include PoW.
+func (w *WalletApi) SignAndPublish(path string, template *AccountBlock) (*AccountBlock, error) {
+	defer common.RecoverStack()
+	if template == nil {
+		return nil, ErrParamIsNull
+	}
+	if template.ChainIdentifier != 0 && template.ChainIdentifier != w.chain.ChainIdentifier() {
+		return nil, errors.Errorf("the block has a different network Id (%d) from the node (%d)", template.ChainIdentifier, w.chain.ChainIdentifier())
+	}
+
+	lb, err := template.ToLedgerBlock()
+	if err != nil {
+		return nil, err
+	}
+	switch lb.BlockType {
+	case nom.BlockTypeUserSend:
+		if err := checkTokenIdValid(w.chain, &lb.TokenStandard); err != nil {
+			return nil, err
+		}
+	case nom.BlockTypeUserReceive:
+	default:
+		return nil, errors.Errorf("can only sign BlockTypeUserSend or BlockTypeUserReceive")
+	}
+
+	keyPair, err := w.manager.FindKeyPair(path, lb.Address)
+	if err != nil {
+		return nil, err
+	}
+	supervisor := vm.NewSupervisor(w.chain, w.z.Consensus())
+	transaction, err := supervisor.GenerateFromTemplate(lb, keyPair.Signer)
+	if err != nil {
+		return nil, err
+	}
+	w.z.Broadcaster().CreateAccountBlock(transaction)
+	w.log.Info("published block", "identifier", transaction.Block.Header())
+	return ledgerAccountBlockToRpc(w.chain, transaction.Block)
+}
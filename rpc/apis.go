@@ -6,6 +6,7 @@ import (
 	"github.com/zenon-network/go-zenon/rpc/api/embedded"
 	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
 	rpc "github.com/zenon-network/go-zenon/rpc/server"
+	"github.com/zenon-network/go-zenon/wallet"
 	"github.com/zenon-network/go-zenon/zenon"
 )
 
@@ -29,6 +30,15 @@ func getApi(z zenon.Zenon, p2p *p2p.Server, apiModule string) []rpc.API {
 				Public:    true,
 			},
 		}
+	case "pow":
+		return []rpc.API{
+			{
+				Namespace: "ledger",
+				Version:   "1.0",
+				Service:   api.NewPoWApi(),
+				Public:    true,
+			},
+		}
 	case "embedded":
 		return []rpc.API{
 			{
@@ -97,3 +107,27 @@ func GetApis(z zenon.Zenon, p2p *p2p.Server, apiModule ...string) []rpc.API {
 func GetPublicApis(z zenon.Zenon, p2p *p2p.Server) []rpc.API {
 	return GetApis(z, p2p, "ledger", "ledgerSubscribe", "embedded", "stats")
 }
+
+// GetWalletApis returns the wallet api, which is not public since it gives access to the keys of the node
+func GetWalletApis(z zenon.Zenon, manager *wallet.Manager) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "wallet",
+			Version:   "1.0",
+			Service:   api.NewWalletApi(z, manager),
+			Public:    false,
+		},
+	}
+}
+
+// GetAdminApis returns the admin api, which is not public since it controls the peers & the process of the node
+func GetAdminApis(z zenon.Zenon, p2p *p2p.Server, dataPath string, shutdown func()) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   api.NewAdminApi(z, p2p, dataPath, shutdown),
+			Public:    false,
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package server
+
+import "context"
+
+// AccessFunc decides whether method, e.g. "ledger.getFrontierMomentum", can be called.
+// A non-nil error rejects the call & is sent to the caller as the JSON-RPC error.
+type AccessFunc func(method string) error
+
+type accessContextKey struct{}
+
+// WithAccessFunc returns a copy of ctx in which every call is checked by access before it runs.
+// It's meant to be used by http middlewares, the context of the request is the parent of the call context
+// for both HTTP & WebSocket.
+func WithAccessFunc(ctx context.Context, access AccessFunc) context.Context {
+	return context.WithValue(ctx, accessContextKey{}, access)
+}
+
+func accessFromContext(ctx context.Context) AccessFunc {
+	access, _ := ctx.Value(accessContextKey{}).(AccessFunc)
+	return access
+}
@@ -54,6 +54,7 @@ var (
 	_ Error = new(invalidRequestError)
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
+	_ Error = new(rateLimitedError)
 )
 
 const defaultErrorCode = -32000
@@ -101,3 +102,15 @@ type invalidParamsError struct{ message string }
 func (e *invalidParamsError) ErrorCode() int { return -32602 }
 
 func (e *invalidParamsError) Error() string { return e.message }
+
+// the client exceeded its rate limit
+type rateLimitedError struct{ method string }
+
+func (e *rateLimitedError) ErrorCode() int { return -32005 }
+
+func (e *rateLimitedError) Error() string {
+	if e.method == "" {
+		return "rate limit exceeded"
+	}
+	return fmt.Sprintf("rate limit exceeded for method %s", e.method)
+}
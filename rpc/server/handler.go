@@ -317,6 +317,16 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if access := accessFromContext(cp.ctx); access != nil {
+		if err := access(msg.Method); err != nil {
+			return msg.errorResponse(err)
+		}
+	}
+	if limit := rateLimitFromContext(cp.ctx); limit != nil {
+		if err := limit.limiter.take(limit.client, msg.Method, h.isRegistered(msg)); err != nil {
+			return msg.errorResponse(err)
+		}
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -339,18 +349,26 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
 	if callb != h.unsubscribeCb {
-		rpcRequestGauge.Inc(1)
+		rpcRequestGauge().Inc(1)
 		if answer.Error != nil {
-			failedReqeustGauge.Inc(1)
+			failedRequestGauge().Inc(1)
 		} else {
-			successfulRequestGauge.Inc(1)
+			successfulRequestGauge().Inc(1)
 		}
-		rpcServingTimer.UpdateSince(start)
+		rpcServingTimer().UpdateSince(start)
 		newRPCServingTimer(msg.Method, answer.Error == nil).UpdateSince(start)
 	}
 	return answer
 }
 
+// isRegistered reports if msg calls a registered method, or subscribes to a registered service
+func (h *handler) isRegistered(msg *jsonrpcMessage) bool {
+	if msg.isSubscribe() || msg.isUnsubscribe() {
+		return h.reg.hasService(msg.namespace())
+	}
+	return h.reg.callback(msg.Method) != nil
+}
+
 // handleSubscribe processes *_subscribe method calls.
 func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
 	if !h.allowSubscribe {
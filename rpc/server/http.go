@@ -250,7 +250,14 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, "Origin", origin)
 	}
 
+	ctx, limit := withRateLimit(ctx, s.rateLimiter, r)
 	w.Header().Set("content-type", contentType)
+	if limit != nil && limit.limiter.exhausted(limit.client) {
+		rateLimitStats.limit("")
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(errorMessage(&rateLimitedError{}))
+		return
+	}
 	codec := newHTTPServerConn(r, w)
 	defer codec.close()
 	s.serveSingleRequest(ctx, codec)
@@ -45,7 +45,10 @@ func ipcListen(endpoint string) (net.Listener, error) {
 	if err != nil {
 		return nil, err
 	}
-	os.Chmod(endpoint, 0600)
+	if err := os.Chmod(endpoint, 0600); err != nil {
+		l.Close()
+		return nil, err
+	}
 	return l, nil
 }
 
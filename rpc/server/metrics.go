@@ -18,22 +18,36 @@ package server
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/metrics"
 )
 
-var (
-	rpcRequestGauge        = metrics.NewRegisteredGauge("rpc/requests", nil)
-	successfulRequestGauge = metrics.NewRegisteredGauge("rpc/success", nil)
-	failedReqeustGauge     = metrics.NewRegisteredGauge("rpc/failure", nil)
-	rpcServingTimer        = metrics.NewRegisteredTimer("rpc/duration/all", nil)
-)
+// The metrics are registered on the first call instead of when the package is loaded,
+// so they are collected if metrics are enabled before the RPC server starts.
+// There are no forced gauges & timers, the ones created while metrics are disabled never collect anything.
+func rpcRequestGauge() metrics.Gauge {
+	return metrics.GetOrRegisterGauge("rpc/requests", nil)
+}
+
+func successfulRequestGauge() metrics.Gauge {
+	return metrics.GetOrRegisterGauge("rpc/success", nil)
+}
+
+func failedRequestGauge() metrics.Gauge {
+	return metrics.GetOrRegisterGauge("rpc/failure", nil)
+}
+
+func rpcServingTimer() metrics.Timer {
+	return metrics.GetOrRegisterTimer("rpc/duration/all", nil)
+}
 
 func newRPCServingTimer(method string, valid bool) metrics.Timer {
 	flag := "success"
 	if !valid {
 		flag = "failure"
 	}
-	m := fmt.Sprintf("rpc/duration/%s/%s", method, flag)
+	// dots aren't allowed in the names of Prometheus metrics
+	m := fmt.Sprintf("rpc/duration/%s/%s", strings.Replace(method, serviceMethodSeparator, "_", -1), flag)
 	return metrics.GetOrRegisterTimer(m, nil)
 }
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// buckets which are full again are dropped after this interval
+	rateLimitCleanupInterval = time.Minute
+)
+
+// RateLimitConfig configures a token bucket for each client, identified by its remote IP or by the
+// name set with WithClientName. Every call takes its weight from the bucket of the client & is
+// rejected if there isn't enough left.
+type RateLimitConfig struct {
+	Rate  float64 // weight refilled per second
+	Burst float64 // capacity of the bucket
+
+	// limits of the clients identified by name, if 0, Rate & Burst are used
+	NamedRate  float64
+	NamedBurst float64
+
+	// weights of methods ("ledger.getDetailedMomentumsByHeight") or namespaces ("embedded.pillar"), default 1.
+	// Weights above the burst of a bucket are clamped to it, so these calls need a full bucket.
+	Weights map[string]float64
+}
+
+type RateLimiter struct {
+	config RateLimitConfig
+
+	mu          sync.Mutex
+	buckets     map[rateLimitClient]*tokenBucket
+	lastCleanup time.Time
+}
+
+type rateLimitClient struct {
+	key   string
+	named bool
+}
+
+type tokenBucket struct {
+	tokens float64
+	rate   float64
+	burst  float64
+	last   time.Time
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.NamedRate == 0 {
+		config.NamedRate = config.Rate
+	}
+	if config.NamedBurst == 0 {
+		config.NamedBurst = config.Burst
+	}
+	rateLimitStats.enable()
+	return &RateLimiter{
+		config:      config,
+		buckets:     make(map[rateLimitClient]*tokenBucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+// weight uses the most specific entry of Weights which matches method
+func (l *RateLimiter) weight(method string) float64 {
+	for name := method; ; {
+		if weight, ok := l.config.Weights[name]; ok {
+			return weight
+		}
+		index := strings.LastIndex(name, serviceMethodSeparator)
+		if index == -1 {
+			return 1
+		}
+		name = name[:index]
+	}
+}
+
+// bucket returns the refilled bucket of client, the caller must hold l.mu
+func (l *RateLimiter) bucket(client rateLimitClient, now time.Time) *tokenBucket {
+	if now.Sub(l.lastCleanup) > rateLimitCleanupInterval {
+		for c, b := range l.buckets {
+			if b.refill(now); b.tokens >= b.burst {
+				delete(l.buckets, c)
+				rateLimitStats.removeClient()
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{rate: l.config.Rate, burst: l.config.Burst, last: now}
+		if client.named {
+			b.rate, b.burst = l.config.NamedRate, l.config.NamedBurst
+		}
+		b.tokens = b.burst
+		l.buckets[client] = b
+		rateLimitStats.addClient()
+	}
+	b.refill(now)
+	return b
+}
+
+// take removes the weight of method from the bucket of client.
+// Only registered methods are counted by name in the stats, since the method is chosen by the client.
+func (l *RateLimiter) take(client rateLimitClient, method string, registered bool) error {
+	weight := l.weight(method)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.bucket(client, time.Now())
+	if weight > b.burst {
+		weight = b.burst
+	}
+	if b.tokens < weight {
+		if registered {
+			rateLimitStats.limit(method)
+		} else {
+			rateLimitStats.limit("")
+		}
+		return &rateLimitedError{method: method}
+	}
+	b.tokens -= weight
+	rateLimitStats.allow()
+	return nil
+}
+
+// exhausted is used to reject HTTP requests before parsing them
+func (l *RateLimiter) exhausted(client rateLimitClient) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.bucket(client, time.Now()).tokens < 1
+}
+
+type clientNameContextKey struct{}
+type rateLimitContextKey struct{}
+
+type rateLimit struct {
+	limiter *RateLimiter
+	client  rateLimitClient
+}
+
+// WithClientName returns a copy of ctx in which the client is identified by name instead of its remote IP.
+// It's meant to be used by http middlewares which authenticate the client.
+func WithClientName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, clientNameContextKey{}, name)
+}
+
+// withRateLimit sets the limiter of all calls made with ctx, if limiter is not nil
+func withRateLimit(ctx context.Context, limiter *RateLimiter, r *http.Request) (context.Context, *rateLimit) {
+	if limiter == nil {
+		return ctx, nil
+	}
+	client := rateLimitClient{}
+	if name, ok := r.Context().Value(clientNameContextKey{}).(string); ok {
+		client.key, client.named = name, true
+	} else if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		client.key = host
+	} else {
+		client.key = r.RemoteAddr
+	}
+	limit := &rateLimit{limiter: limiter, client: client}
+	return context.WithValue(ctx, rateLimitContextKey{}, limit), limit
+}
+
+func rateLimitFromContext(ctx context.Context) *rateLimit {
+	limit, _ := ctx.Value(rateLimitContextKey{}).(*rateLimit)
+	return limit
+}
+
+// RateLimitStats holds the counters of all rate limiters since the node started
+type RateLimitStats struct {
+	Enabled        bool              `json:"enabled"`
+	Clients        int               `json:"clients"` // clients which are currently tracked
+	AllowedCalls   uint64            `json:"allowedCalls"`
+	LimitedCalls   uint64            `json:"limitedCalls"`
+	LimitedMethods map[string]uint64 `json:"limitedMethods"`
+}
+
+type rateLimitCounters struct {
+	mu    sync.Mutex
+	stats RateLimitStats
+}
+
+var rateLimitStats = &rateLimitCounters{stats: RateLimitStats{LimitedMethods: make(map[string]uint64)}}
+
+func (c *rateLimitCounters) enable() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Enabled = true
+}
+func (c *rateLimitCounters) addClient() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Clients += 1
+}
+func (c *rateLimitCounters) removeClient() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Clients -= 1
+}
+func (c *rateLimitCounters) allow() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.AllowedCalls += 1
+}
+func (c *rateLimitCounters) limit(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.LimitedCalls += 1
+	if method != "" {
+		c.stats.LimitedMethods[method] += 1
+	}
+}
+
+// GetRateLimitStats returns a copy of the current counters
+func GetRateLimitStats() *RateLimitStats {
+	rateLimitStats.mu.Lock()
+	defer rateLimitStats.mu.Unlock()
+	stats := rateLimitStats.stats
+	stats.LimitedMethods = make(map[string]uint64, len(rateLimitStats.stats.LimitedMethods))
+	for method, count := range rateLimitStats.stats.LimitedMethods {
+		stats.LimitedMethods[method] = count
+	}
+	return &stats
+}
@@ -46,6 +46,14 @@ type Server struct {
 	idgen    func() ID
 	run      int32
 	codecs   mapset.Set
+
+	rateLimiter *RateLimiter
+}
+
+// SetRateLimiter limits the calls received over HTTP & WS, it must be called before serving any request.
+// The same limiter can be shared by multiple servers.
+func (s *Server) SetRateLimiter(limiter *RateLimiter) {
+	s.rateLimiter = limiter
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -72,6 +80,11 @@ func (s *Server) RegisterName(name string, receiver interface{}) error {
 //
 // Note that codec options are no longer supported.
 func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
+	s.serveCodec(context.Background(), codec)
+}
+
+// serveCodec is the same as ServeCodec, connCtx is the parent of the context of all calls made on the connection.
+func (s *Server) serveCodec(connCtx context.Context, codec ServerCodec) {
 	defer codec.close()
 
 	// Don't serve if server is stopped.
@@ -83,7 +96,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services)
+	c := initClient(connCtx, codec, s.idgen, &s.services)
 	<-codec.closed()
 	c.Close()
 }
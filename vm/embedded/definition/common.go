@@ -256,6 +256,32 @@ func GetQsrDeposit(context db.DB, address *types.Address) (*QsrDeposit, error) {
 	}
 }
 
+// GetQsrDepositList returns the deposits of all addresses which have QSR left
+func GetQsrDepositList(context db.DB) ([]*QsrDeposit, error) {
+	iterator := context.NewIterator(qsrDepositKeyPrefix)
+	defer iterator.Release()
+	list := make([]*QsrDeposit, 0)
+	for {
+		if !iterator.Next() {
+			if iterator.Error() != nil {
+				return nil, iterator.Error()
+			}
+			break
+		}
+
+		if deposit, err := parseQsrDeposit(iterator.Key(), iterator.Value()); err == nil {
+			if deposit.Qsr.Sign() != 0 {
+				list = append(list, deposit)
+			}
+		} else if err == constants.ErrDataNonExistent {
+			continue
+		} else {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
 type LastEpochUpdate struct {
 	LastEpoch int64
 }
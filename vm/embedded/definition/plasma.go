@@ -146,6 +146,29 @@ func GetFusionInfoListByOwner(context db.DB, owner types.Address) ([]*FusionInfo
 	return list, fusedAmount, nil
 }
 
+func GetFusionInfoList(context db.DB) ([]*FusionInfo, error) {
+	iterator := context.NewIterator(fusionInfoKeyPrefix)
+	defer iterator.Release()
+	list := make([]*FusionInfo, 0)
+	for {
+		if !iterator.Next() {
+			if iterator.Error() != nil {
+				return nil, iterator.Error()
+			}
+			break
+		}
+
+		if fusionInfo, err := parseFusionInfo(iterator.Key(), iterator.Value()); err == nil {
+			list = append(list, fusionInfo)
+		} else if err == constants.ErrDataNonExistent {
+			continue
+		} else {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
 type FusedAmount struct {
 	Beneficiary types.Address
 	Amount      *big.Int
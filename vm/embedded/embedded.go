@@ -143,3 +143,58 @@ func GetEmbeddedMethod(context vm_context.AccountVmContext, address types.Addres
 		return nil, constants.ErrContractDoesntExist
 	}
 }
+
+// GetEmbeddedMethodName returns the name of the method of an embedded contract called with abiSelector.
+// Returns the same errors as GetEmbeddedMethod.
+func GetEmbeddedMethodName(address types.Address, abiSelector []byte) (string, error) {
+	if !types.IsEmbeddedAddress(address) {
+		return "", constants.ErrNotContractAddress
+	}
+
+	if p, found := originEmbedded[address]; found {
+		if method, err := p.abi.MethodById(abiSelector); err == nil {
+			return method.Name, nil
+		}
+		return "", constants.ErrContractMethodNotFound
+	} else {
+		return "", constants.ErrContractDoesntExist
+	}
+}
+
+// IsEmbeddedMethodName returns true if any embedded contract has a method called name
+func IsEmbeddedMethodName(name string) bool {
+	for _, p := range originEmbedded {
+		if _, found := p.abi.Methods[name]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeEmbeddedMethod returns the name & the arguments, by input name, of the method of an embedded contract called with data.
+// Returns the same errors as GetEmbeddedMethod or the abi error if the arguments can't be unpacked.
+func DecodeEmbeddedMethod(address types.Address, data []byte) (string, map[string]interface{}, error) {
+	if !types.IsEmbeddedAddress(address) {
+		return "", nil, constants.ErrNotContractAddress
+	}
+	p, found := originEmbedded[address]
+	if !found {
+		return "", nil, constants.ErrContractDoesntExist
+	}
+	if len(data) < 4 {
+		return "", nil, constants.ErrContractMethodNotFound
+	}
+	method, err := p.abi.MethodById(data[0:4])
+	if err != nil {
+		return "", nil, constants.ErrContractMethodNotFound
+	}
+	values, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return "", nil, err
+	}
+	arguments := make(map[string]interface{}, len(values))
+	for i, value := range values {
+		arguments[method.Inputs[i].Name] = value
+	}
+	return method.Name, arguments, nil
+}
@@ -0,0 +1,235 @@
+package events
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/embedded"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+)
+
+const (
+	PillarRegistered   = "pillarRegistered"
+	PillarUpdated      = "pillarUpdated"
+	PillarRevoked      = "pillarRevoked"
+	Delegated          = "delegated"
+	Undelegated        = "undelegated"
+	TokenIssued        = "tokenIssued"
+	TokenMinted        = "tokenMinted"
+	TokenBurned        = "tokenBurned"
+	TokenUpdated       = "tokenUpdated"
+	FusionCreated      = "fusionCreated"
+	FusionCancelled    = "fusionCancelled"
+	StakeStarted       = "stakeStarted"
+	StakeCancelled     = "stakeCancelled"
+	SporkCreated       = "sporkCreated"
+	SporkActivated     = "sporkActivated"
+	SentinelRegistered = "sentinelRegistered"
+	SentinelRevoked    = "sentinelRevoked"
+	QsrDeposited       = "qsrDeposited"
+	QsrWithdrawn       = "qsrWithdrawn"
+	RewardCollected    = "rewardCollected"
+	// ContractCalled is the type of the methods without a dedicated event type
+	ContractCalled = "contractCalled"
+)
+
+var (
+	ErrUnknownEventType  = errors.New("unknown embedded event type")
+	ErrNotEmbeddedFilter = errors.New("filter contract is not an embedded address")
+
+	eventTypes = map[types.Address]map[string]string{
+		types.PillarContract: {
+			definition.RegisterMethodName:       PillarRegistered,
+			definition.LegacyRegisterMethodName: PillarRegistered,
+			definition.UpdatePillarMethodName:   PillarUpdated,
+			definition.RevokeMethodName:         PillarRevoked,
+			definition.DelegateMethodName:       Delegated,
+			definition.UndelegateMethodName:     Undelegated,
+		},
+		types.TokenContract: {
+			definition.IssueMethodName:       TokenIssued,
+			definition.MintMethodName:        TokenMinted,
+			definition.BurnMethodName:        TokenBurned,
+			definition.UpdateTokenMethodName: TokenUpdated,
+		},
+		types.PlasmaContract: {
+			definition.FuseMethodName:       FusionCreated,
+			definition.CancelFuseMethodName: FusionCancelled,
+		},
+		types.StakeContract: {
+			definition.StakeMethodName:       StakeStarted,
+			definition.CancelStakeMethodName: StakeCancelled,
+		},
+		types.SporkContract: {
+			definition.SporkCreateMethodName:   SporkCreated,
+			definition.SporkActivateMethodName: SporkActivated,
+		},
+		types.SentinelContract: {
+			definition.RegisterSentinelMethodName: SentinelRegistered,
+			definition.RevokeSentinelMethodName:   SentinelRevoked,
+		},
+	}
+	// the common methods are implemented by multiple contracts
+	commonEventTypes = map[string]string{
+		definition.DepositQsrMethodName:    QsrDeposited,
+		definition.WithdrawQsrMethodName:   QsrWithdrawn,
+		definition.CollectRewardMethodName: RewardCollected,
+	}
+	allEventTypes = map[string]bool{ContractCalled: true}
+)
+
+func init() {
+	for _, methods := range eventTypes {
+		for _, eventType := range methods {
+			allEventTypes[eventType] = true
+		}
+	}
+	for _, eventType := range commonEventTypes {
+		allEventTypes[eventType] = true
+	}
+}
+
+// Transfer is a contract-send block created by the embedded method, like the tokens of a cancelled fusion or of a minted token
+type Transfer struct {
+	Hash          types.Hash               `json:"hash"`
+	ToAddress     types.Address            `json:"toAddress"`
+	TokenStandard types.ZenonTokenStandard `json:"tokenStandard"`
+	Amount        *big.Int                 `json:"amount"`
+}
+
+// Event is the state change of an embedded contract, decoded from a send-block & the contract-receive block which executed it
+type Event struct {
+	Type             string                   `json:"type"`
+	Contract         types.Address            `json:"contract"`
+	Method           string                   `json:"method"`
+	Arguments        map[string]interface{}   `json:"arguments"`
+	Address          types.Address            `json:"address"`
+	TokenStandard    types.ZenonTokenStandard `json:"tokenStandard"`
+	Amount           *big.Int                 `json:"amount"`
+	Transfers        []*Transfer              `json:"transfers"`
+	SendBlockHash    types.Hash               `json:"sendBlockHash"`
+	ReceiveBlockHash types.Hash               `json:"receiveBlockHash"`
+	MomentumHeight   uint64                   `json:"momentumHeight"`
+}
+
+// IsEventBlock returns true for the contract-receive blocks of embedded contracts which executed the method without errors
+func IsEventBlock(block *nom.AccountBlock) bool {
+	return types.IsEmbeddedAddress(block.Address) && vm.EmbeddedReceiveSucceeded(block)
+}
+
+func eventType(contract types.Address, method string) string {
+	if eventType, ok := eventTypes[contract][method]; ok {
+		return eventType
+	}
+	if eventType, ok := commonEventTypes[method]; ok {
+		return eventType
+	}
+	return ContractCalled
+}
+
+// Decode returns the events of detailed, in the order of the momentum content.
+// momentumStore must include the momentum, the send-blocks are read from it.
+func Decode(momentumStore store.Momentum, detailed *nom.DetailedMomentum) ([]*Event, error) {
+	events := make([]*Event, 0)
+	for _, block := range detailed.AccountBlocks {
+		if !IsEventBlock(block) {
+			continue
+		}
+		sendBlock, err := momentumStore.GetAccountBlockByHash(block.FromBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		if sendBlock == nil {
+			return nil, errors.Errorf("send-block %v of contract-receive %v is missing", block.FromBlockHash, block.Header())
+		}
+		method, arguments, err := embedded.DecodeEmbeddedMethod(block.Address, sendBlock.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the method of send-block %v", sendBlock.Header())
+		}
+
+		transfers := make([]*Transfer, 0, len(block.DescendantBlocks))
+		for _, descendant := range block.DescendantBlocks {
+			transfers = append(transfers, &Transfer{
+				Hash:          descendant.Hash,
+				ToAddress:     descendant.ToAddress,
+				TokenStandard: descendant.TokenStandard,
+				Amount:        descendant.Amount,
+			})
+		}
+		events = append(events, &Event{
+			Type:             eventType(block.Address, method),
+			Contract:         block.Address,
+			Method:           method,
+			Arguments:        arguments,
+			Address:          sendBlock.Address,
+			TokenStandard:    sendBlock.TokenStandard,
+			Amount:           sendBlock.Amount,
+			Transfers:        transfers,
+			SendBlockHash:    sendBlock.Hash,
+			ReceiveBlockHash: block.Hash,
+			MomentumHeight:   detailed.Momentum.Height,
+		})
+	}
+	return events, nil
+}
+
+// Filter selects the events which match all the set fields, an empty list matches any value
+type Filter struct {
+	Types     []string        `json:"types"`
+	Contracts []types.Address `json:"contracts"`
+}
+
+// Check returns an error if the filter contains unknown types or addresses which aren't embedded contracts
+func (f *Filter) Check() error {
+	if f == nil {
+		return nil
+	}
+	for _, eventType := range f.Types {
+		if !allEventTypes[eventType] {
+			return ErrUnknownEventType
+		}
+	}
+	for _, contract := range f.Contracts {
+		if !types.IsEmbeddedAddress(contract) {
+			return ErrNotEmbeddedFilter
+		}
+	}
+	return nil
+}
+
+func (f *Filter) Matches(event *Event) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Types) != 0 && !contains(f.Types, event.Type) {
+		return false
+	}
+	return f.MatchesContract(event.Contract)
+}
+
+// MatchesContract returns true if the events of contract can match the filter
+func (f *Filter) MatchesContract(contract types.Address) bool {
+	if f == nil || len(f.Contracts) == 0 {
+		return true
+	}
+	for _, filtered := range f.Contracts {
+		if filtered == contract {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
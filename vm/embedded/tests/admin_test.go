@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func newAdminTestServer(t *testing.T) *p2p.Server {
+	key, err := crypto.GenerateKey()
+	common.FailIfErr(t, err)
+	server := &p2p.Server{
+		PrivateKey: key,
+		Name:       "admin-test",
+		MaxPeers:   10,
+		ListenAddr: "127.0.0.1:0",
+	}
+	common.FailIfErr(t, server.Start())
+	return server
+}
+
+func waitForPeerCount(t *testing.T, server *p2p.Server, count int) {
+	for i := 0; i < 100; i++ {
+		if server.PeerCount() == count {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("expected %v peers but got %v", count, server.PeerCount())
+}
+
+// - test adding, banning & unbanning peers
+// - test invalid node & log-level parameters
+// - test the data-dir sizes, the producer info & the shutdown request
+func TestAdminApi(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	server := newAdminTestServer(t)
+	defer server.Stop()
+	other := newAdminTestServer(t)
+	defer other.Stop()
+
+	dataPath := t.TempDir()
+	common.FailIfErr(t, os.MkdirAll(filepath.Join(dataPath, "nom"), 0700))
+	common.FailIfErr(t, ioutil.WriteFile(filepath.Join(dataPath, "nom", "000001.log"), make([]byte, 100), 0600))
+	common.FailIfErr(t, ioutil.WriteFile(filepath.Join(dataPath, "config.json"), make([]byte, 10), 0600))
+	shutdown := false
+	adminApi := api.NewAdminApi(z, server, dataPath, func() { shutdown = true })
+
+	common.ExpectString(t, adminApi.AddPeer("enode://invalid").Error(), "invalid node parameter;does not contain node ID")
+	common.ExpectString(t, adminApi.BanPeer("1234").Error(), "invalid node parameter;wrong length, need 64 hex bytes")
+
+	common.FailIfErr(t, adminApi.AddPeer(other.Self().String()))
+	waitForPeerCount(t, server, 1)
+	common.FailIfErr(t, adminApi.BanPeer(other.Self().ID.String()))
+	waitForPeerCount(t, server, 0)
+	common.Json(adminApi.GetBannedPeers()).Equals(t, `
+[
+	"`+other.Self().ID.String()+`"
+]`)
+	common.FailIfErr(t, adminApi.UnbanPeer(other.Self().String()))
+	common.Json(adminApi.GetBannedPeers()).Equals(t, `[]`)
+
+	common.ExpectString(t, adminApi.SetLogLevel("verbose").Error(), "invalid log-level parameter;log15: unknown level: verbose")
+	common.FailIfErr(t, adminApi.SetLogLevel("debug"))
+	common.Json(adminApi.GetLogLevel()).Equals(t, `
+{
+	"level": "dbug"
+}`)
+	common.FailIfErr(t, adminApi.SetLogLevel("info"))
+
+	info, err := adminApi.GetDataInfo()
+	common.FailIfErr(t, err)
+	common.Expect(t, info.Size, int64(110))
+	common.Expect(t, info.Dirs["nom"], int64(100))
+
+	common.Json(adminApi.GetProducerInfo()).Equals(t, `
+{
+	"coinbase": null,
+	"pillarName": ""
+}`)
+
+	common.FailIfErr(t, adminApi.Shutdown())
+	common.ExpectTrue(t, shutdown)
+}
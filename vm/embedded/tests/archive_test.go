@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/archive"
+	"github.com/zenon-network/go-zenon/chain/genesis"
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func newArchiveTestChain(t *testing.T) (chain.Chain, consensus.Consensus) {
+	ch := chain.NewChain(db.NewLevelDBManager(t.TempDir()), genesis.NewGenesis(g.EmbeddedGenesis))
+	cs := consensus.NewConsensus(db.NewMemDB(), ch, nil, true)
+	common.FailIfErr(t, ch.Init())
+	common.FailIfErr(t, cs.Init())
+	common.FailIfErr(t, cs.Start())
+	return ch, cs
+}
+
+// - test that an exported chain is imported with the same frontier momentum
+// - test that importing the same archive again skips all momentums
+// - test that a truncated archive is rejected & the import can be resumed with the full one
+func TestArchive_ExportImport(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	simpleSendSetup(t, z)
+	z.InsertMomentumsTo(10)
+
+	buffer := new(bytes.Buffer)
+	count, err := archive.Export(z.Chain(), buffer, 0, 0)
+	common.FailIfErr(t, err)
+	common.Expect(t, count, uint64(9))
+	data := buffer.Bytes()
+
+	ch, cs := newArchiveTestChain(t)
+	defer ch.Stop()
+	defer cs.Stop()
+	supervisor := vm.NewSupervisor(ch, cs)
+
+	partial, err := archive.Import(ch, supervisor, bytes.NewReader(data[:len(data)/2]))
+	common.ExpectTrue(t, err != nil)
+	common.ExpectTrue(t, partial < 9)
+
+	count, err = archive.Import(ch, supervisor, bytes.NewReader(data))
+	common.FailIfErr(t, err)
+	common.Expect(t, partial+count, uint64(9))
+	expected, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	frontier, err := ch.GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	common.ExpectString(t, frontier.Hash.String(), expected.Hash.String())
+
+	count, err = archive.Import(ch, supervisor, bytes.NewReader(data))
+	common.FailIfErr(t, err)
+	common.Expect(t, count, uint64(0))
+}
+
+// - test that a chain is replayed from the genesis without divergences
+func TestArchive_Verify(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	simpleSendSetup(t, z)
+	z.InsertMomentumsTo(10)
+
+	ch, cs := newArchiveTestChain(t)
+	defer ch.Stop()
+	defer cs.Stop()
+
+	count, err := archive.Verify(z.Chain(), ch, vm.NewSupervisor(ch, cs))
+	common.FailIfErr(t, err)
+	common.Expect(t, count, uint64(9))
+	expected, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	frontier, err := ch.GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	common.ExpectString(t, frontier.Hash.String(), expected.Hash.String())
+}
+
+// divergentGenesis has the genesis momentum of one config, but applies the changes of another one
+type divergentGenesis struct {
+	store.Genesis
+	transaction *nom.MomentumTransaction
+}
+
+func (g *divergentGenesis) GetGenesisTransaction() *nom.MomentumTransaction {
+	return g.transaction
+}
+
+// - test that replaying into a chain whose genesis state differs reports the first momentum, block & embedded method which diverged
+func TestArchive_VerifyDivergence(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	fuse := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.PlasmaContract,
+		Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, g.User1.Address),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertMomentumsTo(5)
+
+	// same genesis momentum, but User1 has one more QSR
+	data, err := json.Marshal(g.EmbeddedGenesis)
+	common.FailIfErr(t, err)
+	config := new(genesis.GenesisConfig)
+	common.FailIfErr(t, json.Unmarshal(data, config))
+	for _, block := range config.GenesisBlocks.Blocks {
+		if block.Address == g.User1.Address {
+			block.BalanceList[types.QsrTokenStandard].Add(block.BalanceList[types.QsrTokenStandard], big.NewInt(g.Zexp))
+		}
+	}
+	original := genesis.NewGenesis(g.EmbeddedGenesis)
+	ch := chain.NewChain(db.NewLevelDBManager(t.TempDir()), &divergentGenesis{
+		Genesis: original,
+		transaction: &nom.MomentumTransaction{
+			Momentum: original.GetGenesisMomentum(),
+			Changes:  genesis.NewGenesis(config).GetGenesisTransaction().Changes,
+		},
+	})
+	cs := consensus.NewConsensus(db.NewMemDB(), ch, nil, true)
+	common.FailIfErr(t, ch.Init())
+	defer ch.Stop()
+	common.FailIfErr(t, cs.Init())
+	common.FailIfErr(t, cs.Start())
+	defer cs.Stop()
+
+	count, err := archive.Verify(z.Chain(), ch, vm.NewSupervisor(ch, cs))
+	common.Expect(t, count, uint64(0))
+	divergence, ok := err.(*archive.Divergence)
+	if !ok {
+		t.Fatalf("expected a divergence but got %v", err)
+	}
+	common.Expect(t, divergence.Momentum.Height, uint64(2))
+	common.ExpectTrue(t, divergence.Block != nil)
+	common.ExpectString(t, divergence.Block.Hash.String(), fuse.Hash.String())
+	common.ExpectString(t, divergence.Method, definition.FuseMethodName)
+	// the blocks are applied, so the divergence is found by comparing the accounts after the momentum
+	common.ExpectString(t, divergence.Reason.Error(), "momentum changes-hash is different than the one computed")
+	common.ExpectTrue(t, strings.Contains(divergence.Error(), "embedded method "+definition.FuseMethodName))
+}
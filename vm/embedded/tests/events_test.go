@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/vm/embedded/events"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+// - test that successful embedded calls are decoded into typed events with their arguments & transfers
+// - test that failed embedded calls don't produce events
+// - test that the events are streamed by the embeddedEvents subscription with a filter
+func TestEmbeddedEvents(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	ledgerApi := api.NewLedgerApi(z)
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.TokenContract,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        constants.TokenIssueAmount,
+		Data: definition.ABIToken.PackMethodPanic(definition.IssueMethodName,
+			"test.tok3n_na-m3", //param.TokenName
+			"TEST",             //param.TokenSymbol
+			"",                 //param.TokenDomain
+			big.NewInt(100),    //param.TotalSupply
+			big.NewInt(1000),   //param.MaxSupply
+			uint8(1),           //param.Decimals
+			true,               //param.IsMintable
+			true,               //param.IsBurnable
+			false,              //param.IsUtility
+		),
+	}).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.PlasmaContract,
+		Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, g.User2.Address),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:   g.User2.Address,
+		ToAddress: types.PlasmaContract,
+		Data:      definition.ABIPlasma.PackMethodPanic(definition.CancelFuseMethodName, types.HexToHashPanic("b3f9b2a54d84db80a0f371766c82fbdc6544fc2ce585fee7d3ef75862c77bb42")),
+	}).Error(t, constants.ErrDataNonExistent)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	list, err := ledgerApi.GetEmbeddedEventsByHeight(1, 10, nil)
+	common.FailIfErr(t, err)
+	common.Expect(t, len(list.List), 2)
+
+	issued := list.List[0]
+	common.ExpectString(t, issued.Type, events.TokenIssued)
+	common.ExpectString(t, issued.Contract.String(), types.TokenContract.String())
+	common.ExpectString(t, issued.Address.String(), g.User1.Address.String())
+	common.ExpectString(t, issued.Arguments["tokenSymbol"].(string), "TEST")
+	common.Expect(t, len(issued.Transfers), 1)
+	common.ExpectString(t, issued.Transfers[0].ToAddress.String(), g.User1.Address.String())
+	common.ExpectString(t, issued.Transfers[0].Amount.String(), "100")
+
+	fused := list.List[1]
+	common.ExpectString(t, fused.Type, events.FusionCreated)
+	common.ExpectString(t, fused.Arguments["address"].(types.Address).String(), g.User2.Address.String())
+	common.ExpectString(t, fused.Amount.String(), big.NewInt(10*g.Zexp).String())
+	common.ExpectTrue(t, fused.MomentumHeight > issued.MomentumHeight)
+
+	list, err = ledgerApi.GetEmbeddedEventsByHeight(1, 10, &events.Filter{Types: []string{events.FusionCreated, events.FusionCancelled}})
+	common.FailIfErr(t, err)
+	common.Expect(t, len(list.List), 1)
+	_, err = ledgerApi.GetEmbeddedEventsByHeight(1, 10, &events.Filter{Types: []string{"unknown"}})
+	common.ExpectError(t, err, events.ErrUnknownEventType)
+
+	server, client := newSubscribeTestClient(t, z)
+	defer server.Stop()
+	defer client.Close()
+	received := make(chan []events.Event, 10)
+	sub, err := client.Subscribe(context.Background(), "ledger", received, "embeddedEvents", &events.Filter{
+		Contracts: []types.Address{types.TokenContract},
+	}, 1)
+	common.FailIfErr(t, err)
+	defer sub.Unsubscribe()
+	select {
+	case notified := <-received:
+		common.Expect(t, len(notified), 1)
+		common.ExpectString(t, notified[0].Type, events.TokenIssued)
+		common.ExpectString(t, notified[0].ReceiveBlockHash.String(), issued.ReceiveBlockHash.String())
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the token issue event")
+	}
+}
+
+// - test that rolled back events are notified to the embeddedEvents subscriptions with the same filter as the inserted ones
+func TestEmbeddedEvents_Rollback(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	server, client := newSubscribeTestClient(t, z)
+	defer server.Stop()
+	defer client.Close()
+	subscribeEvents := func(filter *events.Filter) chan json.RawMessage {
+		received := make(chan json.RawMessage, 10)
+		sub, err := client.Subscribe(context.Background(), "ledger", received, "embeddedEvents", filter)
+		common.FailIfErr(t, err)
+		t.Cleanup(sub.Unsubscribe)
+		return received
+	}
+	fusions := subscribeEvents(&events.Filter{Types: []string{events.FusionCreated}})
+	tokens := subscribeEvents(&events.Filter{Contracts: []types.Address{types.TokenContract}})
+	all := subscribeEvents(nil)
+	frontier, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.TokenContract,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        constants.TokenIssueAmount,
+		Data: definition.ABIToken.PackMethodPanic(definition.IssueMethodName,
+			"test.tok3n_na-m3", //param.TokenName
+			"TEST",             //param.TokenSymbol
+			"",                 //param.TokenDomain
+			big.NewInt(100),    //param.TotalSupply
+			big.NewInt(1000),   //param.MaxSupply
+			uint8(1),           //param.Decimals
+			true,               //param.IsMintable
+			true,               //param.IsBurnable
+			false,              //param.IsUtility
+		),
+	}).Error(t, nil)
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.PlasmaContract,
+		Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, g.User2.Address),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	list, err := api.NewLedgerApi(z).GetEmbeddedEventsByHeight(1, 10, nil)
+	common.FailIfErr(t, err)
+	common.Expect(t, len(list.List), 2)
+	byType := make(map[string]*events.Event)
+	for _, event := range list.List {
+		byType[event.Type] = event
+	}
+	issued, fused := byType[events.TokenIssued], byType[events.FusionCreated]
+	common.ExpectTrue(t, issued != nil && fused != nil)
+
+	insert := z.Chain().AcquireInsert("test rollback")
+	common.FailIfErr(t, z.Chain().RollbackTo(insert, frontier.Identifier()))
+	insert.Unlock()
+
+	receiveRollback := func(ch chan json.RawMessage) []types.Hash {
+		for {
+			select {
+			case notification := <-ch:
+				rollback := new(subscribe.Rollback)
+				if err := json.Unmarshal(notification, rollback); err == nil && rollback.Type == "rollback" {
+					return rollback.AccountBlocks
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected a rollback")
+			}
+		}
+	}
+	common.Expect(t, receiveRollback(fusions), []types.Hash{fused.ReceiveBlockHash})
+	common.Expect(t, receiveRollback(tokens), []types.Hash{issued.ReceiveBlockHash})
+	common.Expect(t, receiveRollback(all), []types.Hash{list.List[0].ReceiveBlockHash, list.List[1].ReceiveBlockHash})
+}
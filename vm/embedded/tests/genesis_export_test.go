@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/genesis"
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+// - test that the exported state passes the genesis checks
+// - test that the chain identifier & spork address are replaced
+// - test that fusions & balances are exported and heights are rebased
+// - test that stakes & QSR deposits are credited back to their owners, so the new genesis has the same funds per address
+func TestGenesisExport(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.PlasmaContract,
+		Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, g.User6.Address),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}).Error(t, nil)
+	z.InsertNewMomentum()
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, constants.StakeTimeMinSec),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(100 * g.Zexp),
+	}).Error(t, nil)
+	z.InsertNewMomentum()
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User2.Address,
+		ToAddress:     types.SentinelContract,
+		Data:          definition.ABISentinel.PackMethodPanic(definition.DepositQsrMethodName),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(50 * g.Zexp),
+	}).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	momentumStore := z.Chain().GetFrontierMomentumStore()
+	frontier, err := momentumStore.GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	common.Expect(t, frontier.Height, uint64(5))
+	config, err := genesis.ExportGenesisConfig(momentumStore, 321, &g.User2.Address)
+	common.FailIfErr(t, err)
+	common.Expect(t, config.ChainIdentifier, uint64(321))
+	common.ExpectString(t, config.SporkAddress.String(), g.User2.Address.String())
+	common.Expect(t, len(genesis.ValidateGenesis(config)), 0)
+
+	activePillars, err := definition.GetPillarsList(momentumStore.GetAccountStore(types.PillarContract).Storage(), true, definition.AnyPillarType)
+	common.FailIfErr(t, err)
+	common.Expect(t, len(config.PillarConfig.Pillars), len(activePillars))
+
+	var fusion *definition.FusionInfo
+	for _, entry := range config.PlasmaConfig.Fusions {
+		if entry.Owner == g.User1.Address && entry.Beneficiary == g.User6.Address {
+			fusion = entry
+		}
+	}
+	common.ExpectTrue(t, fusion != nil)
+	common.ExpectAmount(t, fusion.Amount, big.NewInt(10*g.Zexp))
+	// fused in momentum 2, so it expires FuseExpiration momentums later, rebased on the frontier momentum 5
+	common.Expect(t, fusion.ExpirationHeight, 2+constants.FuseExpiration-5+1)
+
+	// the funds of every account match the source chain, plus the amounts locked by stakes & deposits
+	credits := map[types.Address]map[types.ZenonTokenStandard]*big.Int{
+		g.User1.Address: {types.ZnnTokenStandard: big.NewInt(100 * g.Zexp)},
+		g.User2.Address: {types.QsrTokenStandard: big.NewInt(50 * g.Zexp)},
+	}
+	ch := chain.NewChain(db.NewMemDBManager(db.NewMemDB()), genesis.NewGenesis(config))
+	common.FailIfErr(t, ch.Init())
+	defer ch.Stop()
+	common.Expect(t, ch.ChainIdentifier(), uint64(321))
+	forkedStore := ch.GetFrontierMomentumStore()
+
+	addresses, err := momentumStore.GetAllAccountAddresses()
+	common.FailIfErr(t, err)
+	for _, address := range addresses {
+		if types.IsEmbeddedAddress(address) {
+			continue
+		}
+		for _, zts := range []types.ZenonTokenStandard{types.ZnnTokenStandard, types.QsrTokenStandard} {
+			expected, err := momentumStore.GetAccountStore(address).GetBalance(zts)
+			common.FailIfErr(t, err)
+			if credit, ok := credits[address][zts]; ok {
+				expected = new(big.Int).Add(expected, credit)
+			}
+			balance, err := forkedStore.GetAccountStore(address).GetBalance(zts)
+			common.FailIfErr(t, err)
+			common.ExpectAmount(t, balance, expected)
+		}
+	}
+	for _, address := range []types.Address{types.StakeContract, types.SentinelContract} {
+		balances, err := forkedStore.GetAccountStore(address).GetBalanceMap()
+		common.FailIfErr(t, err)
+		for _, amount := range balances {
+			common.ExpectAmount(t, amount, big.NewInt(0))
+		}
+	}
+}
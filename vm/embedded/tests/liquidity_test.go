@@ -54,7 +54,7 @@ t=2001-09-09T03:47:10+0000 lvl=info msg="received donation" module=embedded cont
 	ledgerApi := api.NewLedgerApi(z)
 
 	z.InsertMomentumsTo(1000)
-	common.Json(ledgerApi.GetAccountInfoByAddress(types.LiquidityContract)).Equals(t, `
+	common.Json(ledgerApi.GetAccountInfoByAddress(types.LiquidityContract, nil)).Equals(t, `
 {
 	"address": "z1qxemdeddedxlyquydytyxxxxxxxxxxxxflaaae",
 	"accountHeight": 10,
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/offline"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/wallet"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+// offlineSignAndPublish builds & signs template like an air-gapped machine would and publishes the resulting JSON
+func offlineSignAndPublish(t *testing.T, z mock.MockZenon, keyFile *wallet.KeyFile, template *nom.AccountBlock) *nom.AccountBlock {
+	address := types.ParseAddressPanic("z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah")
+	frontier, err := z.Chain().GetFrontierAccountStore(address).Frontier()
+	common.FailIfErr(t, err)
+	momentum, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	info, err := offline.NewChainInfo(address, frontier, momentum)
+	common.FailIfErr(t, err)
+
+	block, err := offline.Build(context.Background(), info, template)
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, offline.Sign(block, keyFile, "password"))
+
+	path := filepath.Join(t.TempDir(), "signed.json")
+	common.FailIfErr(t, offline.WriteBlock(path, block))
+	data, err := ioutil.ReadFile(path)
+	common.FailIfErr(t, err)
+	raw := new(api.AccountBlock)
+	common.FailIfErr(t, json.Unmarshal(data, raw))
+	common.FailIfErr(t, api.NewLedgerApi(z).PublishRawTransaction(raw))
+	return block
+}
+
+// - test offline.PackEmbeddedCall with invalid contracts, methods & arguments
+// - test offline.Sign with a wrong password
+// - test that receive & embedded send-blocks built offline are accepted by ledger.publishRawTransaction
+func TestOffline(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	address := types.ParseAddressPanic("z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah")
+	keyFilePath := filepath.Join(t.TempDir(), "keyFile")
+	common.FailIfErr(t, ioutil.WriteFile(keyFilePath, []byte(walletTestKeyFile), 0600))
+	keyFile, err := wallet.ReadKeyFile(keyFilePath)
+	common.FailIfErr(t, err)
+
+	_, _, err = offline.PackEmbeddedCall("missing", "Stake", []string{"1"})
+	common.ExpectString(t, err.Error(), "unknown embedded contract \"missing\", expected one of [accelerator liquidity pillar plasma sentinel spork stake swap token]")
+	_, _, err = offline.PackEmbeddedCall("stake", "Missing", []string{})
+	common.ExpectString(t, err.Error(), "unknown method \"Missing\" for the stake contract")
+	_, _, err = offline.PackEmbeddedCall("stake", "Stake", []string{})
+	common.ExpectString(t, err.Error(), "method Stake(int64) expects 1 arguments but got 0")
+	_, _, err = offline.PackEmbeddedCall("plasma", "Fuse", []string{"z1invalid"})
+	common.ExpectTrue(t, err != nil)
+	toAddress, data, err := offline.PackEmbeddedCall("plasma", "Fuse", []string{address.String()})
+	common.FailIfErr(t, err)
+	common.Expect(t, toAddress, types.PlasmaContract)
+	common.ExpectTrue(t, bytes.Equal(data, definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, address)))
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.PlasmaContract,
+		Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, address),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(50 * g.Zexp),
+	}).Error(t, nil)
+	sendBlock := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertMomentumsTo(4)
+
+	template := &nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserReceive,
+		FromBlockHash: sendBlock.Hash,
+	}
+	info := &offline.ChainInfo{Address: address, MomentumAcknowledged: types.HashHeight{Height: 1}}
+	block, err := offline.Build(context.Background(), info, template)
+	common.FailIfErr(t, err)
+	common.ExpectError(t, offline.Sign(block, keyFile, "wrong-password"), wallet.ErrWrongPassword)
+
+	received := offlineSignAndPublish(t, z, keyFile, template)
+	common.ExpectUint64(t, received.FusedPlasma, constants.AccountBlockBasePlasma)
+	z.InsertNewMomentum()
+
+	toAddress, data, err = offline.PackEmbeddedCall("stake", "Stake", []string{strconv.FormatInt(constants.StakeTimeMinSec, 10)})
+	common.FailIfErr(t, err)
+	offlineSignAndPublish(t, z, keyFile, &nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserSend,
+		ToAddress:     toAddress,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(1 * g.Zexp),
+		Data:          data,
+	})
+	z.InsertMomentumsTo(10)
+
+	z.ExpectBalance(address, types.ZnnTokenStandard, 9*g.Zexp)
+	common.Json(embedded.NewStakeApi(z).GetEntriesByAddress(address, 0, 10, nil)).SubJson(&struct {
+		TotalAmount *big.Int `json:"totalAmount"`
+		Count       int      `json:"count"`
+	}{}).Equals(t, `
+{
+	"totalAmount": 100000000,
+	"count": 1
+}`)
+}
@@ -201,7 +201,7 @@ func TestPillar_RegisterWithoutQsr(t *testing.T) {
 	defer z.StopPanic()
 	defer z.SaveLogs(common.EmbeddedLogger).Equals(t, ``)
 
-	common.Json(pillarApi.GetQsrRegistrationCost()).Equals(t, `15000000000000`)
+	common.Json(pillarApi.GetQsrRegistrationCost(nil)).Equals(t, `15000000000000`)
 	z.ExpectBalance(g.Pillar4.Address, types.ZnnTokenStandard, 16000*g.Zexp)
 	defer z.CallContract(&nom.AccountBlock{
 		Address:       g.Pillar4.Address,
@@ -249,7 +249,7 @@ func TestPillar_DepositQsr(t *testing.T) {
 	}).Error(t, nil)
 	// Add send-blocks
 	z.InsertNewMomentum()
-	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address)).Equals(t, `15000000000000`)
+	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address, nil)).Equals(t, `15000000000000`)
 	z.ExpectBalance(g.Pillar4.Address, types.QsrTokenStandard, 200000*g.Zexp-15000000000000)
 
 	defer z.CallContract(&nom.AccountBlock{
@@ -261,7 +261,7 @@ func TestPillar_DepositQsr(t *testing.T) {
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 	autoreceive(t, z, g.Pillar4.Address)
-	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address)).Equals(t, `0`)
+	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address, nil)).Equals(t, `0`)
 	z.ExpectBalance(g.Pillar4.Address, types.QsrTokenStandard, 200000*g.Zexp)
 
 	// withdraw again, should receive error
@@ -283,7 +283,7 @@ func TestPillar_DepositQsr(t *testing.T) {
 	}).Error(t, nil)
 	// Add send-blocks
 	z.InsertNewMomentum()
-	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address)).Equals(t, `15000000000000`)
+	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address, nil)).Equals(t, `15000000000000`)
 
 	defer z.CallContract(&nom.AccountBlock{
 		Address:   g.Pillar4.Address,
@@ -291,7 +291,7 @@ func TestPillar_DepositQsr(t *testing.T) {
 		Data:      definition.ABIPillars.PackMethodPanic(definition.WithdrawQsrMethodName),
 	}).Error(t, nil)
 	z.InsertMomentumsTo(30)
-	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address)).Equals(t, `0`)
+	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address, nil)).Equals(t, `0`)
 }
 
 // Register a pillar depositing weird amounts of QSR
@@ -374,7 +374,7 @@ t=2001-09-09T01:47:40+0000 lvl=dbug msg=swap-utils-log module=embedded contract=
 t=2001-09-09T01:47:50+0000 lvl=dbug msg="burned ZTS" module=embedded contract=token token="&{Owner:z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62 TokenName:QuasarCoin TokenSymbol:QSR TokenDomain:zenon.network TotalSupply:+134550000000000 MaxSupply:+4611686018427387903 Decimals:8 IsMintable:true IsBurnable:true IsUtility:true TokenStandard:zts1qsrxxxxxxxxxxxxxmrhjll}" burned-amount=15000000000000
 `)
 
-	common.Json(pillarApi.GetQsrRegistrationCost()).Equals(t, `15000000000000`)
+	common.Json(pillarApi.GetQsrRegistrationCost(nil)).Equals(t, `15000000000000`)
 	// deposit QSR for first normal pillar
 	defer z.CallContract(&nom.AccountBlock{
 		Address:       g.Pillar4.Address,
@@ -394,7 +394,7 @@ t=2001-09-09T01:47:50+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	}).Error(t, nil)
 	z.InsertNewMomentum()
 
-	common.Json(pillarApi.GetQsrRegistrationCost()).Equals(t, `16000000000000`)
+	common.Json(pillarApi.GetQsrRegistrationCost(nil)).Equals(t, `16000000000000`)
 	// deposit QSR for second normal pillar
 	defer z.CallContract(&nom.AccountBlock{
 		Address:       g.Pillar5.Address,
@@ -460,7 +460,7 @@ t=2001-09-09T01:47:50+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 		}
 	]
 }`)
-	common.Json(pillarApi.GetQsrRegistrationCost()).Equals(t, `17000000000000`)
+	common.Json(pillarApi.GetQsrRegistrationCost(nil)).Equals(t, `17000000000000`)
 	common.Json(swapApi.GetLegacyPillars()).Equals(t, `
 [
 	{
@@ -540,7 +540,7 @@ t=2001-09-09T01:47:10+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	constants.PillarEpochRevokeTime = 60
 	constants.PillarEpochLockTime = 60
 
-	common.Json(pillarApi.GetQsrRegistrationCost()).Equals(t, `15000000000000`)
+	common.Json(pillarApi.GetQsrRegistrationCost(nil)).Equals(t, `15000000000000`)
 	// deposit QSR for Pillar 4
 	defer z.CallContract(&nom.AccountBlock{
 		Address:       g.Pillar4.Address,
@@ -607,8 +607,8 @@ t=2001-09-09T01:47:10+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	]
 }`)
 
-	common.Json(pillarApi.GetQsrRegistrationCost()).Equals(t, `15000000000000`)
-	common.Json(pillarApi.CheckNameAvailability(g.Pillar4Name)).Equals(t, `false`)
+	common.Json(pillarApi.GetQsrRegistrationCost(nil)).Equals(t, `15000000000000`)
+	common.Json(pillarApi.CheckNameAvailability(g.Pillar4Name, nil)).Equals(t, `false`)
 	// deposit QSR for Pillar 4
 	defer z.CallContract(&nom.AccountBlock{
 		Address:       g.Pillar5.Address,
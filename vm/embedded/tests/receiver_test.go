@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/receiver"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+// waitForAccountHeight waits for the receiver to publish blocks in the background
+func waitForAccountHeight(t *testing.T, z mock.MockZenon, address types.Address, height uint64) {
+	for i := 0; i < 100; i += 1 {
+		if z.Chain().GetFrontierAccountStore(address).Identifier().Height >= height {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("timeout while waiting for %v to reach height %v", address, height)
+}
+
+// - test that the receiver skips locked addresses
+// - test that the receiver only receives allowed tokens, above the min amount
+// - test that the receiver uses fused plasma
+func TestReceiver(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	manager := newWalletManager(t)
+	defer z.StopPanic()
+	defer manager.Stop()
+	ledgerApi := api.NewLedgerApi(z)
+
+	_, err := manager.ImportKeyFile("imported", []byte(walletTestKeyFile), "password")
+	common.FailIfErr(t, err)
+	address := types.ParseAddressPanic("z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah")
+
+	r := receiver.NewReceiver(&receiver.Config{
+		Addresses:      []types.Address{address},
+		TokenStandards: []types.ZenonTokenStandard{types.ZnnTokenStandard},
+		MinAmount:      big.NewInt(1 * g.Zexp),
+	}, z, manager)
+	common.FailIfErr(t, r.Start())
+	defer r.Stop()
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.PlasmaContract,
+		Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, address),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}).Error(t, nil)
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     address,
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(g.Zexp / 10),
+	}, nil, mock.SkipVmChanges)
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertMomentumsTo(4)
+
+	// the address is still locked
+	common.Expect(t, z.Chain().GetFrontierAccountStore(address).Identifier().Height, uint64(0))
+
+	common.FailIfErr(t, manager.Unlock("imported", "password"))
+	z.InsertNewMomentum()
+	waitForAccountHeight(t, z, address, 1)
+	z.InsertNewMomentum()
+
+	z.ExpectBalance(address, types.ZnnTokenStandard, 10*g.Zexp)
+	z.ExpectBalance(address, types.QsrTokenStandard, 0)
+	common.Json(ledgerApi.GetAccountBlocksByHeight(address, 1, 1)).SubJson(&struct {
+		List []*struct {
+			BlockType   uint64 `json:"blockType"`
+			FusedPlasma uint64 `json:"fusedPlasma"`
+			Difficulty  uint64 `json:"difficulty"`
+		} `json:"list"`
+	}{}).Equals(t, `
+{
+	"list": [
+		{
+			"blockType": 3,
+			"fusedPlasma": 21000,
+			"difficulty": 0
+		}
+	]
+}`)
+	common.Json(ledgerApi.GetUnreceivedBlocksByAddress(address, 0, 10)).SubJson(&struct {
+		Count int `json:"count"`
+	}{}).Equals(t, `
+{
+	"count": 2
+}`)
+}
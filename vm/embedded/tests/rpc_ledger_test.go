@@ -6,11 +6,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/syndtr/goleveldb/leveldb"
+
 	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
 	"github.com/zenon-network/go-zenon/chain/nom"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/indexer"
 	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
 	"github.com/zenon-network/go-zenon/zenon/mock"
 )
 
@@ -357,7 +363,7 @@ func ExpectGetAccountBlocksByPage(t *testing.T, z mock.MockZenon) {
 func ExpectGetAccountInfoByAddress(t *testing.T, z mock.MockZenon) {
 	ledgerApi := api.NewLedgerApi(z)
 
-	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address)).Equals(t, `
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"accountHeight": 11,
@@ -733,6 +739,366 @@ func TestRPCLedger_GetDetailedMomentumsByHeight(t *testing.T) {
 	]
 }`)
 }
+
+// - send 100 ZNN from user1 to user2
+// - check balances before and after the send using the momentumHeight parameter
+func TestRPCLedger_GetAccountInfoByAddressAtHeight(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+
+	simpleSendSetup(t, z)
+
+	balances := func() interface{} {
+		return &struct {
+			AccountHeight  uint64 `json:"accountHeight"`
+			BalanceInfoMap map[types.ZenonTokenStandard]struct {
+				Balance *big.Int `json:"balance"`
+			} `json:"balanceInfoMap"`
+		}{}
+	}
+	before := uint64(1)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &before)).SubJson(balances()).Equals(t, `
+{
+	"accountHeight": 1,
+	"balanceInfoMap": {
+		"zts1qsrxxxxxxxxxxxxxmrhjll": {
+			"balance": 12000000000000
+		},
+		"zts1znnxxxxxxxxxxxxx9z4ulx": {
+			"balance": 1200000000000
+		}
+	}
+}`)
+	after := uint64(2)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &after)).SubJson(balances()).Equals(t, `
+{
+	"accountHeight": 2,
+	"balanceInfoMap": {
+		"zts1qsrxxxxxxxxxxxxxmrhjll": {
+			"balance": 12000000000000
+		},
+		"zts1znnxxxxxxxxxxxxx9z4ulx": {
+			"balance": 1190000000000
+		}
+	}
+}`)
+
+	zero := uint64(0)
+	tooBig := uint64(100)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &zero)).Error(t, api.ErrHeightParamIsZero)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &tooBig)).Error(t, api.ErrMomentumHeightTooBig)
+
+	// the state is only served for the last MaxHistoryDepth momentums
+	z.Config().MaxHistoryDepth = 2
+	z.InsertMomentumsTo(5)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &after)).Error(t, api.ErrStateNotAvailable)
+	common.Json(embedded.NewTokenApi(z).GetAll(0, 10, &after)).Error(t, api.ErrStateNotAvailable)
+	oldest := uint64(3)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &oldest)).SubJson(balances()).Equals(t, `
+{
+	"accountHeight": 2,
+	"balanceInfoMap": {
+		"zts1qsrxxxxxxxxxxxxxmrhjll": {
+			"balance": 12000000000000
+		},
+		"zts1znnxxxxxxxxxxxxx9z4ulx": {
+			"balance": 1190000000000
+		}
+	}
+}`)
+}
+
+// waitForIndexer waits until the indexer of z reaches the frontier momentum
+func waitForIndexer(t *testing.T, z mock.MockZenon) {
+	for i := 0; i < 100; i += 1 {
+		frontier, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+		common.FailIfErr(t, err)
+		indexed, err := z.Indexer().Frontier()
+		common.FailIfErr(t, err)
+		if indexed == frontier.Identifier() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("indexer didn't reach the frontier momentum")
+}
+
+// - send 100 ZNN from user1 to user2 & receive it
+// - filter by counterparty, token-standard, direction & timestamp
+// - rollback the momentum which confirms the receive-block and check that it's no longer returned
+func TestRPCLedger_GetAccountBlocksByFilter(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+
+	simpleSendSetup(t, z)
+	waitForIndexer(t, z)
+
+	summary := func() interface{} {
+		return &struct {
+			Count int  `json:"count"`
+			More  bool `json:"more"`
+			List  []struct {
+				BlockType uint64        `json:"blockType"`
+				Height    uint64        `json:"height"`
+				Address   types.Address `json:"address"`
+				ToAddress types.Address `json:"toAddress"`
+				Detail    struct {
+					MomentumHeight uint64 `json:"momentumHeight"`
+				} `json:"confirmationDetail"`
+			} `json:"list"`
+		}{}
+	}
+	incoming := indexer.DirectionIncoming
+	outgoing := indexer.DirectionOutgoing
+	znn := types.ZnnTokenStandard
+
+	common.Json(ledgerApi.GetAccountBlocksByFilter(&api.AccountBlockFilter{
+		Address:       g.User2.Address,
+		Counterparty:  &g.User1.Address,
+		TokenStandard: &znn,
+		Direction:     &incoming,
+	}, 0, 10)).SubJson(summary()).Equals(t, `
+{
+	"count": 1,
+	"more": false,
+	"list": [
+		{
+			"blockType": 3,
+			"height": 2,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f",
+			"confirmationDetail": {
+				"momentumHeight": 3
+			}
+		}
+	]
+}`)
+	common.Json(ledgerApi.GetAccountBlocksByFilter(&api.AccountBlockFilter{
+		Address:   g.User1.Address,
+		Direction: &outgoing,
+	}, 0, 10)).SubJson(summary()).Equals(t, `
+{
+	"count": 1,
+	"more": false,
+	"list": [
+		{
+			"blockType": 2,
+			"height": 2,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"confirmationDetail": {
+				"momentumHeight": 2
+			}
+		}
+	]
+}`)
+	// all blocks of user2, including the genesis receive-blocks, one per page
+	common.Json(ledgerApi.GetAccountBlocksByFilter(&api.AccountBlockFilter{
+		Address: g.User2.Address,
+	}, 0, 1)).SubJson(summary()).Equals(t, `
+{
+	"count": 2,
+	"more": true,
+	"list": [
+		{
+			"blockType": 3,
+			"height": 2,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f",
+			"confirmationDetail": {
+				"momentumHeight": 3
+			}
+		}
+	]
+}`)
+	// receive-block is confirmed at timestamp 1000000020
+	to := int64(1000000020)
+	common.Json(ledgerApi.GetAccountBlocksByFilter(&api.AccountBlockFilter{
+		Address:     g.User2.Address,
+		Direction:   &incoming,
+		ToTimestamp: &to,
+	}, 0, 10)).SubJson(summary()).Equals(t, `
+{
+	"count": 1,
+	"more": false,
+	"list": [
+		{
+			"blockType": 1,
+			"height": 1,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f",
+			"confirmationDetail": {
+				"momentumHeight": 1
+			}
+		}
+	]
+}`)
+
+	momentum, err := z.Chain().GetFrontierMomentumStore().GetMomentumByHeight(2)
+	common.FailIfErr(t, err)
+	insert := z.Chain().AcquireInsert("test rollback")
+	common.FailIfErr(t, z.Chain().RollbackTo(insert, momentum.Identifier()))
+	insert.Unlock()
+	waitForIndexer(t, z)
+
+	common.Json(ledgerApi.GetAccountBlocksByFilter(&api.AccountBlockFilter{
+		Address:      g.User2.Address,
+		Counterparty: &g.User1.Address,
+	}, 0, 10)).SubJson(summary()).Equals(t, `
+{
+	"count": 0,
+	"more": false,
+	"list": []
+}`)
+}
+
+// - check that queries are rejected while the indexer is behind the frontier, instead of indexing in the call
+// - check that the indexer catches up in the background
+func TestRPCLedger_GetAccountBlocksByFilterIndexing(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	simpleSendSetup(t, z)
+	z.InsertMomentumsTo(20)
+
+	indexerDB, err := leveldb.OpenFile(t.TempDir(), nil)
+	common.FailIfErr(t, err)
+	fresh := indexer.NewIndexer(indexerDB, z.Chain())
+	common.FailIfErr(t, fresh.Init())
+	filter := &indexer.Filter{Address: g.User2.Address}
+	_, _, err = fresh.GetAccountBlocksByFilter(filter, 0, 10)
+	common.ExpectError(t, err, indexer.ErrIndexingInProgress)
+
+	common.FailIfErr(t, fresh.Start())
+	defer fresh.Stop()
+	for i := 0; ; i += 1 {
+		hashes, count, err := fresh.GetAccountBlocksByFilter(filter, 0, 1)
+		if err == indexer.ErrIndexingInProgress && i < 100 {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		common.FailIfErr(t, err)
+		common.Expect(t, len(hashes), 1)
+		common.Expect(t, count, 2)
+		break
+	}
+}
+
+// - follow a send-block from the account-pool until it's received
+// - check the embedded result of a failed sentinel registration
+func TestRPCLedger_GetBlockStatus(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+
+	sendBlock := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     g.User2.Address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(100 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	common.Json(ledgerApi.GetBlockStatus(sendBlock.Hash)).HideHashes().Equals(t, `
+{
+	"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+	"blockType": 2,
+	"inAccountPool": true,
+	"confirmationDetail": null,
+	"receiveBlockHash": null,
+	"receiveConfirmed": false,
+	"embeddedResult": null
+}`)
+
+	z.InsertNewMomentum()
+	common.Json(ledgerApi.GetBlockStatus(sendBlock.Hash)).HideHashes().Equals(t, `
+{
+	"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+	"blockType": 2,
+	"inAccountPool": false,
+	"confirmationDetail": {
+		"numConfirmations": 1,
+		"momentumHeight": 2,
+		"momentumHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"momentumTimestamp": 1000000010
+	},
+	"receiveBlockHash": null,
+	"receiveConfirmed": false,
+	"embeddedResult": null
+}`)
+
+	autoreceive(t, z, g.User2.Address)
+	common.Json(ledgerApi.GetBlockStatus(sendBlock.Hash)).HideHashes().Equals(t, `
+{
+	"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+	"blockType": 2,
+	"inAccountPool": false,
+	"confirmationDetail": {
+		"numConfirmations": 1,
+		"momentumHeight": 2,
+		"momentumHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"momentumTimestamp": 1000000010
+	},
+	"receiveBlockHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	"receiveConfirmed": false,
+	"embeddedResult": null
+}`)
+
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(ledgerApi.GetBlockStatus(sendBlock.Hash)).HideHashes().Equals(t, `
+{
+	"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+	"blockType": 2,
+	"inAccountPool": false,
+	"confirmationDetail": {
+		"numConfirmations": 3,
+		"momentumHeight": 2,
+		"momentumHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"momentumTimestamp": 1000000010
+	},
+	"receiveBlockHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	"receiveConfirmed": true,
+	"embeddedResult": null
+}`)
+
+	registerBlock := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.SentinelContract,
+		Data:          definition.ABISentinel.PackMethodPanic(definition.RegisterSentinelMethodName),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        constants.SentinelZnnRegisterAmount,
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(ledgerApi.GetBlockStatus(registerBlock.Hash)).HideHashes().Equals(t, `
+{
+	"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+	"blockType": 2,
+	"inAccountPool": false,
+	"confirmationDetail": {
+		"numConfirmations": 2,
+		"momentumHeight": 5,
+		"momentumHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"momentumTimestamp": 1000000040
+	},
+	"receiveBlockHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	"receiveConfirmed": true,
+	"embeddedResult": {
+		"success": false,
+		"descendantBlocks": [
+			"XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+		]
+	}
+}`)
+
+	common.Json(ledgerApi.GetBlockStatus(types.ZeroHash)).Equals(t, `null`)
+}
+
 func TestRPCLedger_Errors(t *testing.T) {
 	z := mock.NewMockZenon(t)
 	ledgerApi := api.NewLedgerApi(z)
@@ -742,3 +1108,150 @@ func TestRPCLedger_Errors(t *testing.T) {
 	common.Json(ledgerApi.GetDetailedMomentumsByHeight(1, 1234)).Error(t, api.ErrCountParamTooBig)
 	common.Json(ledgerApi.GetAccountBlocksByPage(types.ZeroAddress, 0, 1234)).Error(t, api.ErrPageSizeParamTooBig)
 }
+
+// - simulate a sentinel registration without any QSR deposited
+// - the embedded error is reported and nothing is inserted in the chain
+func TestRPCLedger_SimulateBlock(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+	z.InsertNewMomentum()
+
+	common.Json(ledgerApi.SimulateBlock(&api.AccountBlock{AccountBlock: nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.SentinelContract,
+		Data:          definition.ABISentinel.PackMethodPanic(definition.RegisterSentinelMethodName),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        constants.SentinelZnnRegisterAmount,
+	}})).HideHashes().Equals(t, `
+{
+	"sendBlock": {
+		"version": 1,
+		"chainIdentifier": 100,
+		"blockType": 2,
+		"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"previousHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"height": 2,
+		"momentumAcknowledged": {
+			"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+			"height": 2
+		},
+		"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+		"toAddress": "z1qxemdeddedxsentynelxxxxxxxxxxxxxwy0r2r",
+		"amount": 500000000000,
+		"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+		"fromBlockHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"descendantBlocks": [],
+		"data": "TdI1Fw==",
+		"fusedPlasma": 52500,
+		"difficulty": 0,
+		"nonce": "0000000000000000",
+		"basePlasma": 52500,
+		"usedPlasma": 52500,
+		"changesHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"publicKey": null,
+		"signature": null
+	},
+	"receiveBlock": {
+		"version": 1,
+		"chainIdentifier": 100,
+		"blockType": 5,
+		"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"previousHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"height": 2,
+		"momentumAcknowledged": {
+			"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+			"height": 2
+		},
+		"address": "z1qxemdeddedxsentynelxxxxxxxxxxxxxwy0r2r",
+		"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f",
+		"amount": null,
+		"tokenStandard": "zts1qqqqqqqqqqqqqqqqtq587y",
+		"fromBlockHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"descendantBlocks": [
+			{
+				"version": 1,
+				"chainIdentifier": 100,
+				"blockType": 4,
+				"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+				"previousHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+				"height": 1,
+				"momentumAcknowledged": {
+					"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+					"height": 2
+				},
+				"address": "z1qxemdeddedxsentynelxxxxxxxxxxxxxwy0r2r",
+				"toAddress": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+				"amount": 500000000000,
+				"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+				"fromBlockHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+				"descendantBlocks": null,
+				"data": null,
+				"fusedPlasma": 0,
+				"difficulty": 0,
+				"nonce": "0000000000000000",
+				"basePlasma": 0,
+				"usedPlasma": 0,
+				"changesHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+				"publicKey": null,
+				"signature": null
+			}
+		],
+		"data": "AAAAAAAAAAI=",
+		"fusedPlasma": 0,
+		"difficulty": 0,
+		"nonce": "0000000000000000",
+		"basePlasma": 0,
+		"usedPlasma": 0,
+		"changesHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		"publicKey": null,
+		"signature": null
+	},
+	"descendantBlocks": [
+		{
+			"version": 1,
+			"chainIdentifier": 100,
+			"blockType": 4,
+			"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+			"previousHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+			"height": 1,
+			"momentumAcknowledged": {
+				"hash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+				"height": 2
+			},
+			"address": "z1qxemdeddedxsentynelxxxxxxxxxxxxxwy0r2r",
+			"toAddress": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"amount": 500000000000,
+			"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+			"fromBlockHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+			"descendantBlocks": null,
+			"data": null,
+			"fusedPlasma": 0,
+			"difficulty": 0,
+			"nonce": "0000000000000000",
+			"basePlasma": 0,
+			"usedPlasma": 0,
+			"changesHash": "XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+			"publicKey": null,
+			"signature": null
+		}
+	],
+	"balanceChanges": [
+		{
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+			"before": 1200000000000,
+			"after": 700000000000
+		}
+	],
+	"basePlasma": 52500,
+	"usedPlasma": 52500,
+	"embeddedError": "not enough deposited Qsr"
+}`)
+	common.Json(ledgerApi.GetFrontierAccountBlock(g.User1.Address)).SubJson(&struct {
+		Height uint64 `json:"height"`
+	}{}).Equals(t, `
+{
+	"height": 1
+}`)
+}
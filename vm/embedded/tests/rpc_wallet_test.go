@@ -0,0 +1,204 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/wallet"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+const walletTestKeyFile = `
+{
+	"baseAddress": "z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah",
+	"crypto": {
+		"cipherName": "aes-256-gcm",
+		"kdf": "argon2.IDKey",
+		"cipherData": "0xef9a2297c637d4fdd6f94604d4fff6ecb31c3663c4ec828afb4d428b4c2bcee54b20c12cfacd5dc1b486b2e3ef3b9a65",
+		"nonce": "0x6a4b92fc51efe77b77403811",
+		"argon2Params": {
+			"salt": "0x188a8f7551f42ca4cff90f60489dc29a"
+		}
+	},
+	"version": 1,
+	"timestamp": 1792204412
+}`
+
+func newWalletManager(t *testing.T) *wallet.Manager {
+	manager := wallet.New(&wallet.Config{WalletDir: t.TempDir()})
+	common.FailIfErr(t, manager.Start())
+	return manager
+}
+
+// - test wallet.importKeyFile & wallet.createKeyFile with invalid names
+// - test wallet.unlock & wallet.getAddresses
+// - test wallet.signAndPublish for receive & send blocks
+// - test wallet.lock
+func TestRPCWallet(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	manager := newWalletManager(t)
+	walletApi := api.NewWalletApi(z, manager)
+	defer z.StopPanic()
+	defer manager.Stop()
+
+	common.Json(walletApi.ImportKeyFile("imported", []byte(walletTestKeyFile), "wrong-password")).Error(t, wallet.ErrWrongPassword)
+	common.Json(walletApi.ImportKeyFile("imported", []byte(walletTestKeyFile), "password")).SubJson(&struct {
+		BaseAddress types.Address `json:"baseAddress"`
+		Unlocked    bool          `json:"unlocked"`
+	}{}).Equals(t, `
+{
+	"baseAddress": "z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah",
+	"unlocked": false
+}`)
+	common.Json(walletApi.ImportKeyFile("imported", []byte(walletTestKeyFile), "password")).Error(t, wallet.ErrKeyFileAlreadyExists)
+	common.Json(walletApi.CreateKeyFile("../created", "password")).Error(t, wallet.ErrKeyFileInvalidName)
+	_, err := walletApi.CreateKeyFile("created", "password")
+	common.FailIfErr(t, err)
+	list, err := walletApi.ListKeyFiles()
+	common.FailIfErr(t, err)
+	common.Expect(t, len(list), 2)
+
+	common.Json(walletApi.GetAddresses("imported", 0, 2)).Error(t, wallet.ErrKeyStoreLocked)
+	common.ExpectError(t, walletApi.Unlock("imported", "wrong-password", nil), wallet.ErrWrongPassword)
+	common.FailIfErr(t, walletApi.Unlock("imported", "password", nil))
+	common.Json(walletApi.GetAddresses("imported", 0, 2)).Equals(t, `
+[
+	{
+		"index": 0,
+		"address": "z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah"
+	},
+	{
+		"index": 1,
+		"address": "z1qpe2830scdvanpzvsgc8cyjy6uk4q0eqgze5f9"
+	}
+]`)
+	address := types.ParseAddressPanic("z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah")
+
+	// fuse plasma for the wallet address & send it some ZNN
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.PlasmaContract,
+		Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, address),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}).Error(t, nil)
+	sendBlock := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertMomentumsTo(4)
+
+	common.Json(walletApi.SignAndPublish("imported", &api.AccountBlock{AccountBlock: nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserReceive,
+		Address:       g.User1.Address,
+		FromBlockHash: sendBlock.Hash,
+	}})).Error(t, wallet.ErrAddressNotFound)
+	common.Json(walletApi.SignAndPublish("imported", &api.AccountBlock{AccountBlock: nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserReceive,
+		Address:       address,
+		FromBlockHash: sendBlock.Hash,
+	}})).SubJson(&struct {
+		BlockType uint64        `json:"blockType"`
+		Height    uint64        `json:"height"`
+		Address   types.Address `json:"address"`
+	}{}).Equals(t, `
+{
+	"blockType": 3,
+	"height": 1,
+	"address": "z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah"
+}`)
+	z.InsertNewMomentum() // refresh plasma
+	common.Json(walletApi.SignAndPublish("imported", &api.AccountBlock{AccountBlock: nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserSend,
+		Address:       address,
+		ToAddress:     g.User1.Address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(4 * g.Zexp),
+	}})).SubJson(&struct {
+		BlockType uint64        `json:"blockType"`
+		Height    uint64        `json:"height"`
+		Address   types.Address `json:"address"`
+		Amount    *big.Int      `json:"amount"`
+	}{}).Equals(t, `
+{
+	"blockType": 2,
+	"height": 2,
+	"address": "z1qqgjesy0udulnqh6fs8cd4q5etuzk3pncl9nah",
+	"amount": 400000000
+}`)
+	z.InsertNewMomentum()
+	z.ExpectBalance(address, types.ZnnTokenStandard, 6*g.Zexp)
+
+	common.FailIfErr(t, walletApi.Lock("imported"))
+	common.Json(walletApi.SignAndPublish("imported", &api.AccountBlock{AccountBlock: nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserSend,
+		Address:       address,
+		ToAddress:     g.User1.Address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(1 * g.Zexp),
+	}})).Error(t, wallet.ErrKeyStoreLocked)
+	common.ExpectError(t, walletApi.Lock("missing"), wallet.ErrKeyStoreNotFound)
+}
+
+// - test that wallet.unlock locks the keyFile again after the given duration
+func TestRPCWallet_UnlockDuration(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	manager := newWalletManager(t)
+	walletApi := api.NewWalletApi(z, manager)
+	defer z.StopPanic()
+	defer manager.Stop()
+
+	_, err := walletApi.ImportKeyFile("imported", []byte(walletTestKeyFile), "password")
+	common.FailIfErr(t, err)
+
+	duration := uint64(1)
+	common.FailIfErr(t, walletApi.Unlock("imported", "password", &duration))
+	unlocked, err := manager.IsUnlocked("imported")
+	common.FailIfErr(t, err)
+	common.ExpectTrue(t, unlocked)
+
+	time.Sleep(1500 * time.Millisecond)
+	unlocked, err = manager.IsUnlocked("imported")
+	common.FailIfErr(t, err)
+	common.ExpectTrue(t, !unlocked)
+}
+
+// - test wallet.importMnemonic with & without passphrase
+// - test wallet.exportMnemonic
+func TestRPCWallet_Mnemonic(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	manager := newWalletManager(t)
+	walletApi := api.NewWalletApi(z, manager)
+	defer z.StopPanic()
+	defer manager.Stop()
+
+	mnemonic := "route become dream access impulse price inform obtain engage ski believe awful absent pig thing vibrant possible exotic flee pepper marble rural fire fancy"
+	passphrase := "passphrase"
+	common.Json(walletApi.ImportMnemonic("invalid", "route become dream", "password", nil)).Error(t, wallet.ErrInvalidMnemonic)
+	common.Json(walletApi.ImportMnemonic("plain", mnemonic, "password", nil)).SubJson(&struct {
+		BaseAddress types.Address `json:"baseAddress"`
+	}{}).Equals(t, `
+{
+	"baseAddress": "z1qqjnwjjpnue8xmmpanz6csze6tcmtzzdtfsww7"
+}`)
+	common.Json(walletApi.ImportMnemonic("protected", mnemonic, "password", &passphrase)).SubJson(&struct {
+		BaseAddress types.Address `json:"baseAddress"`
+	}{}).Equals(t, `
+{
+	"baseAddress": "z1qpcree5t2mtwepmh9nlhmj79v407ga25g738sq"
+}`)
+
+	common.Json(walletApi.ExportMnemonic("protected", "wrong-password")).Error(t, wallet.ErrWrongPassword)
+	exported, err := walletApi.ExportMnemonic("protected", "password")
+	common.FailIfErr(t, err)
+	common.ExpectString(t, exported, mnemonic)
+}
@@ -125,8 +125,8 @@ func TestSimple_ContractCall(t *testing.T) {
 	z.InsertNewMomentum() // cemented send blocks
 	z.InsertNewMomentum() // cemented pillar receive-blocks
 
-	common.Json(pillarApi.GetDepositedQsr(g.User1.Address)).Equals(t, `150000000000`)
-	common.Json(pillarApi.GetDelegatedPillar(g.User1.Address)).Equals(t, `
+	common.Json(pillarApi.GetDepositedQsr(g.User1.Address, nil)).Equals(t, `150000000000`)
+	common.Json(pillarApi.GetDelegatedPillar(g.User1.Address, nil)).Equals(t, `
 {
 	"name": "TEST-pillar-1",
 	"status": 1,
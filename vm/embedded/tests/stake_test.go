@@ -139,13 +139,13 @@ t=2001-09-09T05:17:20+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 
 	// half of Epoch4
 	z.InsertMomentumsTo((30 + 3*60) * 6)
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
 	"qsrAmount": 2166666666666
 }`)
-	common.Json(stakeApi.GetUncollectedReward(g.User2.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User2.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
 	"znnAmount": 0,
@@ -384,7 +384,7 @@ t=2001-09-09T06:46:40+0000 lvl=dbug msg="revoked stake entry" module=embedded co
 		Amount:        big.NewInt(10 * g.Zexp),
 	}).Error(t, nil)
 	z.InsertMomentumsTo(10)
-	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"totalAmount": 1000000000,
 	"totalWeightedAmount": 1100000000,
@@ -412,7 +412,7 @@ t=2001-09-09T06:46:40+0000 lvl=dbug msg="revoked stake entry" module=embedded co
 	// cancel stake while staking period is still active
 	z.InsertMomentumsTo(20)
 
-	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"totalAmount": 1000000000,
 	"totalWeightedAmount": 1100000000,
@@ -435,7 +435,7 @@ t=2001-09-09T06:46:40+0000 lvl=dbug msg="revoked stake entry" module=embedded co
 	}).Error(t, constants.RevokeNotDue)
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
-	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"totalAmount": 1000000000,
 	"totalWeightedAmount": 1100000000,
@@ -631,7 +631,7 @@ t=2001-09-09T07:16:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	// Half of Epoch1
 	z.InsertMomentumsTo(30 * 6)
 	z.ExpectBalance(types.StakeContract, types.ZnnTokenStandard, 170*g.Zexp)
-	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).HideHashes().Equals(t, `
 {
 	"totalAmount": 2000000000,
 	"totalWeightedAmount": 2300000000,
@@ -655,7 +655,7 @@ t=2001-09-09T07:16:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 		}
 	]
 }`)
-	common.Json(stakeApi.GetEntriesByAddress(g.User5.Address, 0, 10)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User5.Address, 0, 10, nil)).HideHashes().Equals(t, `
 {
 	"totalAmount": 0,
 	"totalWeightedAmount": 0,
@@ -665,7 +665,7 @@ t=2001-09-09T07:16:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 
 	// Half of Epoch2
 	z.InsertMomentumsTo((30 + 60) * 6)
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
@@ -745,7 +745,7 @@ t=2001-09-09T07:16:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 
 	// Half of Epoch5
 	z.InsertMomentumsTo((30 + 4*60) * 6)
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
@@ -759,7 +759,7 @@ t=2001-09-09T07:16:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
@@ -825,19 +825,19 @@ t=2001-09-09T07:16:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 
 	// Half of Epoch6
 	z.InsertMomentumsTo((30 + 5*60) * 6)
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
 	"qsrAmount": 49429657794
 }`)
-	common.Json(stakeApi.GetUncollectedReward(g.User2.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User2.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
 	"znnAmount": 0,
 	"qsrAmount": 1866191334722
 }`)
-	common.Json(stakeApi.GetUncollectedReward(g.User3.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User3.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qrs2lpccnsneglhnnfwvlsj0qncnxjnwlfmjac",
 	"znnAmount": 0,
@@ -852,7 +852,7 @@ t=2001-09-09T07:16:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
@@ -861,13 +861,13 @@ t=2001-09-09T07:16:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	autoreceive(t, z, g.User1.Address)
 	// qsr after collect
 	z.ExpectBalance(g.User1.Address, types.QsrTokenStandard, 12334521663189)
-	common.Json(stakeApi.GetUncollectedReward(g.User2.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User2.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
 	"znnAmount": 0,
 	"qsrAmount": 1866191334722
 }`)
-	common.Json(stakeApi.GetUncollectedReward(g.User3.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User3.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qrs2lpccnsneglhnnfwvlsj0qncnxjnwlfmjac",
 	"znnAmount": 0,
@@ -960,7 +960,7 @@ t=2001-09-09T05:17:00+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 
 	// Half of Epoch4
 	z.InsertMomentumsTo((30 + 3*60) * 6)
-	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"totalAmount": 1000000000,
 	"totalWeightedAmount": 1000000000,
@@ -976,13 +976,13 @@ t=2001-09-09T05:17:00+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 		}
 	]
 }`)
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
 	"qsrAmount": 3000000000000
 }`)
-	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).HideHashes().Equals(t, `
 {
 	"totalAmount": 1000000000,
 	"totalWeightedAmount": 1000000000,
@@ -1005,13 +1005,13 @@ t=2001-09-09T05:17:00+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	}).Error(t, nil)
 	z.InsertNewMomentum()
 	z.ExpectBalance(types.StakeContract, types.ZnnTokenStandard, 0*g.Zexp)
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
 	"qsrAmount": 3000000000000
 }`)
-	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10)).HideHashes().Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).HideHashes().Equals(t, `
 {
 	"totalAmount": 0,
 	"totalWeightedAmount": 0,
@@ -1144,13 +1144,13 @@ func TestStake_CheckRpc(t *testing.T) {
 	"count": 0,
 	"list": []
 }`)
-	common.Json(stakeApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(stakeApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
 	"qsrAmount": 0
 }`)
-	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(stakeApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"totalAmount": 0,
 	"totalWeightedAmount": 0,
@@ -0,0 +1,428 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain"
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func newSubscribeTestClient(t *testing.T, z mock.MockZenon) (*subscribe.Server, *rpc.Client) {
+	server := subscribe.NewSubscribeServer(z.Chain())
+	common.FailIfErr(t, server.Init())
+	common.FailIfErr(t, server.Start())
+	rpcServer := rpc.NewServer()
+	common.FailIfErr(t, rpcServer.RegisterName("ledger", server.Api))
+	return server, rpc.DialInProc(rpcServer)
+}
+
+func receiveMomentumHeights(t *testing.T, ch chan []subscribe.Momentum, count int) []uint64 {
+	heights := make([]uint64, 0, count)
+	for len(heights) < count {
+		select {
+		case momentums := <-ch:
+			for _, momentum := range momentums {
+				heights = append(heights, momentum.Height)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %v momentums but got %v", count, heights)
+		}
+	}
+	select {
+	case momentums := <-ch:
+		t.Fatalf("unexpected momentums %v after %v", momentums, heights)
+	case <-time.After(100 * time.Millisecond):
+	}
+	return heights
+}
+
+// - test that stored momentums are replayed before the live ones, without gaps or duplicates
+// - test that stored account-blocks are replayed for the subscribed address
+func TestSubscribe_FromHeight(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	simpleSendSetup(t, z)
+	z.InsertMomentumsTo(10)
+
+	server, client := newSubscribeTestClient(t, z)
+	defer server.Stop()
+	defer client.Close()
+
+	momentums := make(chan []subscribe.Momentum, 100)
+	sub, err := client.Subscribe(context.Background(), "ledger", momentums, "momentums", 2)
+	common.FailIfErr(t, err)
+	defer sub.Unsubscribe()
+	common.Expect(t, receiveMomentumHeights(t, momentums, 9), []uint64{2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	z.InsertMomentumsTo(12)
+	common.Expect(t, receiveMomentumHeights(t, momentums, 2), []uint64{11, 12})
+
+	blocks := make(chan []subscribe.AccountBlock, 100)
+	blocksSub, err := client.Subscribe(context.Background(), "ledger", blocks, "accountBlocksByAddress", g.User1.Address, 2)
+	common.FailIfErr(t, err)
+	defer blocksSub.Unsubscribe()
+	select {
+	case received := <-blocks:
+		common.Expect(t, len(received), 1)
+		common.ExpectString(t, received[0].Address.String(), g.User1.Address.String())
+		common.ExpectString(t, received[0].ToAddress.String(), g.User2.Address.String())
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the stored account-block of the address")
+	}
+}
+
+func receiveNotifications(t *testing.T, ch chan json.RawMessage, count int) []string {
+	notifications := make([]string, 0, count)
+	for len(notifications) < count {
+		select {
+		case notification := <-ch:
+			notifications = append(notifications, string(notification))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %v notifications but got %v", count, notifications)
+		}
+	}
+	return notifications
+}
+
+// - test that every subscription type is notified about rolled back momentums, in order with the inserted ones
+// - test that a momentum inserted again at a rolled back height is notified
+func TestSubscribe_Rollback(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	simpleSendSetup(t, z)
+	z.InsertMomentumsTo(4)
+
+	server, client := newSubscribeTestClient(t, z)
+	defer server.Stop()
+	defer client.Close()
+
+	momentums := make(chan json.RawMessage, 100)
+	sub, err := client.Subscribe(context.Background(), "ledger", momentums, "momentums", 4)
+	common.FailIfErr(t, err)
+	defer sub.Unsubscribe()
+	blocks := make(chan json.RawMessage, 100)
+	blocksSub, err := client.Subscribe(context.Background(), "ledger", blocks, "accountBlocksByAddress", g.User1.Address, 2)
+	common.FailIfErr(t, err)
+	defer blocksSub.Unsubscribe()
+
+	store := z.Chain().GetFrontierMomentumStore()
+	momentum2, err := store.GetMomentumByHeight(2)
+	common.FailIfErr(t, err)
+	momentum3, err := store.GetMomentumByHeight(3)
+	common.FailIfErr(t, err)
+	momentum4, err := store.GetMomentumByHeight(4)
+	common.FailIfErr(t, err)
+	send := momentum2.Content[0]
+	receive := momentum3.Content[0]
+	genesis, err := store.GetMomentumByHeight(1)
+	common.FailIfErr(t, err)
+
+	common.Expect(t, len(receiveNotifications(t, momentums, 1)), 1)
+	common.Expect(t, len(receiveNotifications(t, blocks, 1)), 1)
+	insert := z.Chain().AcquireInsert("test rollback")
+	common.FailIfErr(t, z.Chain().RollbackTo(insert, genesis.Identifier()))
+	insert.Unlock()
+
+	expected := func(momentum *nom.Momentum, hashes ...types.Hash) string {
+		data, err := json.Marshal(&subscribe.Rollback{
+			Type:          "rollback",
+			Momentum:      &subscribe.Momentum{Hash: momentum.Hash, Height: momentum.Height},
+			AccountBlocks: append([]types.Hash{}, hashes...),
+		})
+		common.FailIfErr(t, err)
+		return string(data)
+	}
+	common.Expect(t, receiveNotifications(t, momentums, 3), []string{
+		expected(momentum4),
+		expected(momentum3, receive.Hash),
+		expected(momentum2, send.Hash),
+	})
+	common.Expect(t, receiveNotifications(t, blocks, 1), []string{
+		expected(momentum2, send.Hash),
+	})
+
+	z.InsertNewMomentum()
+	frontier, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	common.Expect(t, frontier.Height, uint64(2))
+	data, err := json.Marshal([]*subscribe.Momentum{{Hash: frontier.Hash, Height: frontier.Height}})
+	common.FailIfErr(t, err)
+	common.Expect(t, receiveNotifications(t, momentums, 1), []string{string(data)})
+}
+
+func receiveBlocks(t *testing.T, ch chan []subscribe.AccountBlock) []subscribe.AccountBlock {
+	all := make([]subscribe.AccountBlock, 0)
+	for {
+		select {
+		case blocks := <-ch:
+			all = append(all, blocks...)
+		case <-time.After(200 * time.Millisecond):
+			return all
+		}
+	}
+}
+
+// - test that only the account-blocks matching all the fields of the filter are notified
+// - test that embedded methods are decoded from the block data
+// - test that invalid filters are rejected
+func TestSubscribe_Filter(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	simpleSendSetup(t, z)
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.PlasmaContract,
+		Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, g.User1.Address),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertMomentumsTo(10)
+
+	server, client := newSubscribeTestClient(t, z)
+	defer server.Stop()
+	defer client.Close()
+	receive := func(filter *subscribe.AccountBlockFilter) []subscribe.AccountBlock {
+		blocks := make(chan []subscribe.AccountBlock, 100)
+		sub, err := client.Subscribe(context.Background(), "ledger", blocks, "accountBlocksByFilter", filter, 2)
+		common.FailIfErr(t, err)
+		defer sub.Unsubscribe()
+		return receiveBlocks(t, blocks)
+	}
+
+	deposits := receive(&subscribe.AccountBlockFilter{
+		ToAddresses:    []types.Address{g.User2.Address, g.User3.Address},
+		TokenStandards: []types.ZenonTokenStandard{types.ZnnTokenStandard, types.QsrTokenStandard},
+		BlockTypes:     []uint64{nom.BlockTypeUserSend},
+		MinAmount:      big.NewInt(1 * g.Zexp),
+	})
+	common.Expect(t, len(deposits), 1)
+	common.ExpectString(t, deposits[0].Address.String(), g.User1.Address.String())
+	common.ExpectString(t, deposits[0].Amount.String(), big.NewInt(100*g.Zexp).String())
+
+	common.Expect(t, len(receive(&subscribe.AccountBlockFilter{
+		ToAddresses: []types.Address{g.User2.Address},
+		MinAmount:   big.NewInt(101 * g.Zexp),
+	})), 0)
+
+	fusions := receive(&subscribe.AccountBlockFilter{
+		EmbeddedContracts: []types.Address{types.PlasmaContract},
+		Methods:           []string{definition.FuseMethodName},
+	})
+	common.Expect(t, len(fusions), 1)
+	common.ExpectString(t, fusions[0].ToAddress.String(), types.PlasmaContract.String())
+	common.ExpectString(t, fusions[0].TokenStandard.String(), types.QsrTokenStandard.String())
+
+	blocks := make(chan []subscribe.AccountBlock)
+	_, err := client.Subscribe(context.Background(), "ledger", blocks, "accountBlocksByFilter", &subscribe.AccountBlockFilter{
+		EmbeddedContracts: []types.Address{g.User1.Address},
+	})
+	common.ExpectString(t, err.Error(), subscribe.ErrFilterNotEmbeddedContract.Error())
+	_, err = client.Subscribe(context.Background(), "ledger", blocks, "accountBlocksByFilter", &subscribe.AccountBlockFilter{
+		Methods: []string{"NotAMethod"},
+	})
+	common.ExpectString(t, err.Error(), subscribe.ErrFilterUnknownMethod.Error())
+}
+
+// - test that momentums inserted & rolled back while the stored momentums are replayed are notified without gaps or duplicates
+func TestSubscribe_RollbackWhileBackfilling(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	z.InsertMomentumsTo(100)
+
+	server, client := newSubscribeTestClient(t, z)
+	defer server.Stop()
+	defer client.Close()
+
+	// momentums are inserted & rolled back while the subscription is installed & backfilled
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i += 1 {
+			frontier, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+			common.FailIfErr(t, err)
+			z.InsertMomentumsTo(frontier.Height + 3)
+			insert := z.Chain().AcquireInsert("test rollback")
+			common.FailIfErr(t, z.Chain().RollbackTo(insert, types.HashHeight{Hash: frontier.Hash, Height: frontier.Height}))
+			insert.Unlock()
+			z.InsertMomentumsTo(frontier.Height + 1)
+		}
+	}()
+	notifications := make(chan json.RawMessage, 1000)
+	sub, err := client.Subscribe(context.Background(), "ledger", notifications, "momentums", 1)
+	common.FailIfErr(t, err)
+	defer sub.Unsubscribe()
+	<-done
+
+	// the chain of the subscription, built from the notified momentums & rollbacks
+	chain := make([]subscribe.Momentum, 0)
+	apply := func(notification json.RawMessage) {
+		rollback := new(subscribe.Rollback)
+		if err := json.Unmarshal(notification, rollback); err == nil && rollback.Type == "rollback" {
+			last := chain[len(chain)-1]
+			if last.Hash != rollback.Momentum.Hash || last.Height != rollback.Momentum.Height {
+				t.Fatalf("rollback of %v but the last notified momentum is %v", rollback.Momentum, last)
+			}
+			chain = chain[:len(chain)-1]
+			return
+		}
+		momentums := make([]subscribe.Momentum, 0)
+		common.FailIfErr(t, json.Unmarshal(notification, &momentums))
+		for _, momentum := range momentums {
+			if momentum.Height != uint64(len(chain))+1 {
+				t.Fatalf("momentum %v notified after %v momentums", momentum.Height, len(chain))
+			}
+			chain = append(chain, momentum)
+		}
+	}
+
+	for {
+		select {
+		case notification := <-notifications:
+			apply(notification)
+			continue
+		case <-time.After(500 * time.Millisecond):
+		}
+		break
+	}
+	store := z.Chain().GetFrontierMomentumStore()
+	common.Expect(t, len(chain), 110)
+	for _, momentum := range chain {
+		expected, err := store.GetMomentumByHeight(momentum.Height)
+		common.FailIfErr(t, err)
+		common.ExpectString(t, momentum.Hash.String(), expected.Hash.String())
+	}
+}
+
+// - test that subscriptions too far behind the frontier momentum are rejected
+// - test that the concurrent backfills are limited & released once finished
+// - test that a subscription with too many pending events is dropped
+func TestSubscribe_BackfillLimits(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	z.InsertMomentumsTo(200)
+
+	server := subscribe.NewSubscribeServer(z.Chain())
+	common.FailIfErr(t, server.Init())
+	server.SetBackfillLimits(subscribe.BackfillLimits{
+		MaxDepth:      150,
+		MaxConcurrent: 1,
+		MaxPending:    0,
+	})
+	rpcServer := rpc.NewServer()
+	common.FailIfErr(t, rpcServer.RegisterName("ledger", server.Api))
+	client := rpc.DialInProc(rpcServer)
+	defer client.Close()
+
+	notifications := make(chan json.RawMessage, 1000)
+	_, err := client.Subscribe(context.Background(), "ledger", notifications, "momentums", 1)
+	common.ExpectString(t, err.Error(), subscribe.ErrBackfillTooDeep.Error())
+
+	// the worker isn't started, so the first backfill isn't finished
+	sub, err := client.Subscribe(context.Background(), "ledger", notifications, "momentums", 190)
+	common.FailIfErr(t, err)
+	defer sub.Unsubscribe()
+	_, err = client.Subscribe(context.Background(), "ledger", notifications, "momentums", 195)
+	common.ExpectString(t, err.Error(), subscribe.ErrTooManyBackfills.Error())
+	live, err := client.Subscribe(context.Background(), "ledger", make(chan json.RawMessage, 1000), "momentums")
+	common.FailIfErr(t, err)
+	defer live.Unsubscribe()
+
+	common.FailIfErr(t, server.Start())
+	defer server.Stop()
+	common.Expect(t, len(receiveNotifications(t, notifications, 1)), 1)
+
+	// any event received while replaying drops the subscription, retried since the backfill might finish first
+	dropped, err := json.Marshal(&subscribe.Dropped{Type: "dropped", Reason: "too many events while replaying stored momentums"})
+	common.FailIfErr(t, err)
+	for attempt := 0; ; attempt += 1 {
+		if attempt == 20 {
+			t.Fatal("expected the subscription to be dropped")
+		}
+		received := make(chan json.RawMessage, 1000)
+		sub, err := client.Subscribe(context.Background(), "ledger", received, "momentums", 60)
+		if err != nil {
+			// the previous backfill is finishing
+			common.ExpectString(t, err.Error(), subscribe.ErrTooManyBackfills.Error())
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		receiveNotifications(t, received, 1)
+		z.InsertNewMomentum()
+		last := receiveNotifications(t, received, 1)[0]
+		for found := false; !found; {
+			select {
+			case notification := <-received:
+				last = string(notification)
+			case <-time.After(200 * time.Millisecond):
+				found = true
+			}
+		}
+		sub.Unsubscribe()
+		if last == string(dropped) {
+			break
+		}
+	}
+}
+
+// failingChain replays momentums from a store which fails to read them
+type failingChain struct {
+	chain.Chain
+}
+
+func (c *failingChain) GetFrontierMomentumStore() store.Momentum {
+	return &failingStore{Momentum: c.Chain.GetFrontierMomentumStore()}
+}
+
+type failingStore struct {
+	store.Momentum
+}
+
+func (s *failingStore) GetMomentumsByHeight(uint64, bool, uint64) ([]*nom.Momentum, error) {
+	return nil, errors.New("failing store")
+}
+
+// - test that a subscription whose stored momentums can't be replayed is dropped, instead of continuing with a gap
+func TestSubscribe_BackfillFailure(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	z.InsertMomentumsTo(10)
+
+	server := subscribe.NewSubscribeServer(&failingChain{Chain: z.Chain()})
+	common.FailIfErr(t, server.Init())
+	common.FailIfErr(t, server.Start())
+	defer server.Stop()
+	rpcServer := rpc.NewServer()
+	common.FailIfErr(t, rpcServer.RegisterName("ledger", server.Api))
+	client := rpc.DialInProc(rpcServer)
+	defer client.Close()
+
+	notifications := make(chan json.RawMessage, 100)
+	sub, err := client.Subscribe(context.Background(), "ledger", notifications, "momentums", 2)
+	common.FailIfErr(t, err)
+	defer sub.Unsubscribe()
+	dropped, err := json.Marshal(&subscribe.Dropped{Type: "dropped", Reason: "failed to replay stored momentums"})
+	common.FailIfErr(t, err)
+	common.Expect(t, receiveNotifications(t, notifications, 1), []string{string(dropped)})
+
+	// live momentums aren't notified after the subscription is dropped
+	z.InsertMomentumsTo(12)
+	select {
+	case notification := <-notifications:
+		t.Fatalf("unexpected notification %v", string(notification))
+	case <-time.After(200 * time.Millisecond):
+	}
+}
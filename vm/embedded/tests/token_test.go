@@ -42,7 +42,7 @@ func issueTokenSetup(t *testing.T, z mock.MockZenon) {
 	z.InsertNewMomentum() // cemented send block
 	z.InsertNewMomentum() // cemented token-receive-block
 
-	tokenList, err := tokenAPI.GetByOwner(g.User1.Address, 0, 10)
+	tokenList, err := tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)
 	common.FailIfErr(t, err)
 
 	common.Json(tokenList, err).Equals(t, `
@@ -138,7 +138,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="issued ZTS" module=embedded contract=to
 	z.InsertNewMomentum()
 	autoreceive(t, z, g.User1.Address)
 	z.InsertNewMomentum()
-	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address)).Equals(t, `
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"accountHeight": 3,
@@ -201,7 +201,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="issued ZTS" module=embedded contract=to
 		TokenStandard: zts,
 		Amount:        common.BigP255,
 	}, verifier.ErrABAmountTooBig, mock.NoVmChanges)
-	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address)).Equals(t, `
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"accountHeight": 3,
@@ -265,7 +265,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="issued ZTS" module=embedded contract=to
 	}, nil, mock.SkipVmChanges)
 	z.InsertNewMomentum()
 	autoreceive(t, z, g.User2.Address)
-	common.Json(ledgerApi.GetAccountInfoByAddress(g.User2.Address)).Equals(t, `
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User2.Address, nil)).Equals(t, `
 {
 	"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
 	"accountHeight": 2,
@@ -456,7 +456,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="issued ZTS" module=embedded contract=to
 	z.InsertNewMomentum() // cemented update block
 	z.InsertNewMomentum() // cemented token receive-blocks
 	// Check that token is still the same
-	common.Json(tokenAPI.GetByZts(customZts)).Equals(t, `
+	common.Json(tokenAPI.GetByZts(customZts, nil)).Equals(t, `
 {
 	"name": "test.tok3n_na-m3",
 	"symbol": "TEST",
@@ -498,7 +498,7 @@ t=2001-09-09T01:47:10+0000 lvl=dbug msg="updated ZTS" module=embedded contract=t
 	}).Error(t, nil)
 	z.InsertNewMomentum() // cemented update block
 	z.InsertNewMomentum() // cemented token receive-blocks
-	common.Json(tokenAPI.GetByOwner(g.User2.Address, 0, 5)).HideHashes().Equals(t, `
+	common.Json(tokenAPI.GetByOwner(g.User2.Address, 0, 5, nil)).HideHashes().Equals(t, `
 {
 	"count": 1,
 	"list": [
@@ -517,7 +517,7 @@ t=2001-09-09T01:47:10+0000 lvl=dbug msg="updated ZTS" module=embedded contract=t
 		}
 	]
 }`)
-	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 5)).HideHashes().Equals(t, `
+	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 5, nil)).HideHashes().Equals(t, `
 {
 	"count": 0,
 	"list": []
@@ -552,7 +552,7 @@ t=2001-09-09T01:47:10+0000 lvl=dbug msg="updated ZTS" module=embedded contract=t
 	}).Error(t, nil)
 	z.InsertNewMomentum() // cemented update block
 	z.InsertNewMomentum() // cemented token receive-blocks
-	common.Json(tokenAPI.GetByOwner(g.User2.Address, 0, 5)).HideHashes().Equals(t, `
+	common.Json(tokenAPI.GetByOwner(g.User2.Address, 0, 5, nil)).HideHashes().Equals(t, `
 {
 	"count": 1,
 	"list": [
@@ -595,12 +595,12 @@ func TestToken_CheckRpc(t *testing.T) {
 t=2001-09-09T01:46:50+0000 lvl=dbug msg="issued ZTS" module=embedded contract=token token="{Owner:z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz TokenName:test.tok3n_na-m3 TokenSymbol:TEST TokenDomain: TotalSupply:+100 MaxSupply:+1000 Decimals:1 IsMintable:true IsBurnable:true IsUtility:false TokenStandard:zts103tsa5yqngu9cfpj2m0z9u}"
 `)
 
-	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"count": 0,
 	"list": []
 }`)
-	common.Json(tokenAPI.GetAll(0, 10)).Equals(t, `
+	common.Json(tokenAPI.GetAll(0, 10, nil)).Equals(t, `
 {
 	"count": 2,
 	"list": [
@@ -632,11 +632,11 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="issued ZTS" module=embedded contract=to
 		}
 	]
 }`)
-	common.Json(tokenAPI.GetByZts(customZts)).Equals(t, "null")
+	common.Json(tokenAPI.GetByZts(customZts, nil)).Equals(t, "null")
 
 	issueTokenSetup(t, z)
 
-	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"count": 1,
 	"list": [
@@ -655,7 +655,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="issued ZTS" module=embedded contract=to
 		}
 	]
 }`)
-	common.Json(tokenAPI.GetAll(0, 10)).Equals(t, `
+	common.Json(tokenAPI.GetAll(0, 10, nil)).Equals(t, `
 {
 	"count": 3,
 	"list": [
@@ -700,7 +700,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="issued ZTS" module=embedded contract=to
 		}
 	]
 }`)
-	common.Json(tokenAPI.GetByZts(customZts)).Equals(t, `
+	common.Json(tokenAPI.GetByZts(customZts, nil)).Equals(t, `
 {
 	"name": "test.tok3n_na-m3",
 	"symbol": "TEST",
@@ -750,7 +750,7 @@ t=2001-09-09T01:47:30+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	}).Error(t, nil)
 	z.InsertNewMomentum() // cemented send block
 	z.InsertNewMomentum() // cemented token receive-blocks
-	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"count": 1,
 	"list": [
@@ -772,7 +772,7 @@ t=2001-09-09T01:47:30+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	autoreceive(t, z, g.User1.Address)
 
 	// get customZts of the new token
-	tokens, err := tokenAPI.GetByOwner(g.User1.Address, 0, 10)
+	tokens, err := tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)
 	common.FailIfErr(t, err)
 	customZts := tokens.List[0].ZenonTokenStandard
 	z.ExpectBalance(g.User1.Address, customZts, 100)
@@ -838,7 +838,7 @@ t=2001-09-09T01:50:30+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 
 	// Issue Token
 	issueTokenSetup(t, z)
-	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"count": 1,
 	"list": [
@@ -858,7 +858,7 @@ t=2001-09-09T01:50:30+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	]
 }`)
 	autoreceive(t, z, g.User1.Address)
-	tokens, err := tokenAPI.GetByOwner(g.User1.Address, 0, 10)
+	tokens, err := tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)
 	common.FailIfErr(t, err)
 	customZts := tokens.List[0].ZenonTokenStandard
 	z.ExpectBalance(g.User1.Address, customZts, 100)
@@ -934,7 +934,7 @@ t=2001-09-09T01:50:30+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	autoreceive(t, z, g.User3.Address)
 	z.ExpectBalance(g.User3.Address, customZts, 2)
 
-	tokens, err = tokenAPI.GetByOwner(g.User1.Address, 0, 10)
+	tokens, err = tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)
 	common.FailIfErr(t, err)
 	customZts = tokens.List[0].ZenonTokenStandard
 	common.ExpectAmount(t, tokens.List[0].TotalSupply, big.NewInt(100))
@@ -949,7 +949,7 @@ t=2001-09-09T01:50:30+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	}).Error(t, nil)
 	z.InsertNewMomentum() // cemented send block
 	z.InsertNewMomentum() // cemented token receive-blocks
-	tokens, err = tokenAPI.GetByOwner(g.User1.Address, 0, 10)
+	tokens, err = tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)
 	customZts = tokens.List[0].ZenonTokenStandard
 	common.FailIfErr(t, err)
 	common.ExpectAmount(t, tokens.List[0].TotalSupply, big.NewInt(99))
@@ -985,7 +985,7 @@ t=2001-09-09T01:50:30+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	z.ExpectBalance(g.User1.Address, customZts, 98)
 	z.ExpectBalance(g.User3.Address, customZts, 1)
 
-	tokens, err = tokenAPI.GetByOwner(g.User2.Address, 0, 10)
+	tokens, err = tokenAPI.GetByOwner(g.User2.Address, 0, 10, nil)
 	common.FailIfErr(t, err)
 	common.ExpectAmount(t, tokens.List[0].TotalSupply, big.NewInt(99))
 	z.ExpectBalance(types.TokenContract, customZts, 0)
@@ -1053,7 +1053,7 @@ t=2001-09-09T01:48:10+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	z.InsertNewMomentum() // cemented token-receive-block
 	autoreceive(t, z, g.User1.Address)
 	// get customZts of the new token
-	tokens, err := tokenAPI.GetByOwner(g.User1.Address, 0, 10)
+	tokens, err := tokenAPI.GetByOwner(g.User1.Address, 0, 10, nil)
 	common.FailIfErr(t, err)
 	customZts := tokens.List[0].ZenonTokenStandard
 	z.ExpectBalance(g.User1.Address, customZts, 150)
@@ -1100,7 +1100,7 @@ t=2001-09-09T01:48:10+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	z.InsertNewMomentum() // cemented token-receive-block
 	z.ExpectBalance(g.User2.Address, customZts, 0)
 
-	common.Json(tokenAPI.GetByZts(customZts)).Equals(t, `
+	common.Json(tokenAPI.GetByZts(customZts, nil)).Equals(t, `
 {
 	"name": "test.tok3n_na-m3",
 	"symbol": "TEST",
@@ -0,0 +1,262 @@
+package tests
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
+	"github.com/zenon-network/go-zenon/webhook"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+type webhookRequest struct {
+	signature string
+	delivery  string
+	batch     *webhook.Batch
+	body      []byte
+}
+
+// newWebhookServer answers with the status returned by status & forwards every request to the returned channel
+func newWebhookServer(t *testing.T, status func() int) (*httptest.Server, chan *webhookRequest) {
+	requests := make(chan *webhookRequest, 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		common.FailIfErr(t, err)
+		batch := new(webhook.Batch)
+		common.FailIfErr(t, json.Unmarshal(body, batch))
+		requests <- &webhookRequest{
+			signature: r.Header.Get(webhook.SignatureHeader),
+			delivery:  r.Header.Get(webhook.DeliveryHeader),
+			batch:     batch,
+			body:      body,
+		}
+		w.WriteHeader(status())
+	}))
+	return server, requests
+}
+
+func receiveWebhookRequest(t *testing.T, requests chan *webhookRequest) *webhookRequest {
+	select {
+	case request := <-requests:
+		return request
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a webhook request")
+		return nil
+	}
+}
+
+func decodeWebhookItems(t *testing.T, batch *webhook.Batch) []*webhook.Item {
+	items := make([]*webhook.Item, 0, len(batch.Items))
+	for _, data := range batch.Items {
+		item := new(webhook.Item)
+		common.FailIfErr(t, json.Unmarshal(data, item))
+		items = append(items, item)
+	}
+	return items
+}
+
+// - test that only the account-blocks matching the filter are delivered
+// - test that requests are signed with the secret of the hook
+func TestWebhook_Delivery(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	httpServer, requests := newWebhookServer(t, func() int { return http.StatusOK })
+	defer httpServer.Close()
+
+	secret := []byte("webhook-secret")
+	dir := t.TempDir()
+	sink, err := webhook.NewSink(&webhook.Config{
+		QueuePath:      filepath.Join(dir, "queue"),
+		DeadLetterPath: filepath.Join(dir, "dead-letter.log"),
+		Hooks: []*webhook.HookConfig{{
+			Name:   "deposits",
+			URL:    httpServer.URL,
+			Secret: secret,
+			Filter: &subscribe.AccountBlockFilter{
+				ToAddresses: []types.Address{g.User2.Address},
+			},
+		}},
+	}, z.Chain())
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, sink.Start())
+	defer sink.Stop()
+
+	simpleSendSetup(t, z)
+	request := receiveWebhookRequest(t, requests)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(request.body)
+	common.ExpectString(t, request.signature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	common.ExpectTrue(t, request.delivery != "")
+	common.ExpectString(t, request.batch.Hook, "deposits")
+
+	items := decodeWebhookItems(t, request.batch)
+	common.Expect(t, len(items), 1)
+	common.ExpectString(t, items[0].Type, webhook.InsertType)
+	common.Expect(t, items[0].Momentum.Height, uint64(2))
+	common.Expect(t, len(items[0].AccountBlocks), 1)
+	common.ExpectString(t, items[0].AccountBlocks[0].Address.String(), g.User1.Address.String())
+	common.ExpectString(t, items[0].AccountBlocks[0].Amount.String(), "10000000000")
+
+	z.InsertMomentumsTo(6)
+	select {
+	case request := <-requests:
+		t.Fatalf("unexpected webhook request %v", string(request.body))
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// - test that failed requests are retried & moved to the dead-letter log after MaxAttempts
+// - test that queued items which weren't delivered before Stop are delivered after a restart, with a new delivery id
+func TestWebhook_RetryAndPersistence(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	failing := int32(1)
+	httpServer, requests := newWebhookServer(t, func() int {
+		if atomic.LoadInt32(&failing) == 1 {
+			return http.StatusInternalServerError
+		}
+		return http.StatusOK
+	})
+	defer httpServer.Close()
+
+	dir := t.TempDir()
+	config := &webhook.Config{
+		QueuePath:      filepath.Join(dir, "queue"),
+		DeadLetterPath: filepath.Join(dir, "dead-letter.log"),
+		Hooks: []*webhook.HookConfig{{
+			Name:           "momentums",
+			URL:            httpServer.URL,
+			Secret:         []byte("webhook-secret"),
+			Momentums:      true,
+			BatchSize:      1,
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+		}},
+	}
+	sink, err := webhook.NewSink(config, z.Chain())
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, sink.Start())
+
+	z.InsertNewMomentum()
+	first := receiveWebhookRequest(t, requests)
+	second := receiveWebhookRequest(t, requests)
+	common.ExpectString(t, second.delivery, first.delivery)
+	common.ExpectString(t, string(second.body), string(first.body))
+
+	var letter struct {
+		Hook     string          `json:"hook"`
+		Attempts int             `json:"attempts"`
+		Batch    json.RawMessage `json:"batch"`
+	}
+	for i := 0; ; i += 1 {
+		file, err := os.Open(config.DeadLetterPath)
+		if err == nil {
+			scanner := bufio.NewScanner(file)
+			common.ExpectTrue(t, scanner.Scan())
+			common.FailIfErr(t, json.Unmarshal(scanner.Bytes(), &letter))
+			file.Close()
+			break
+		}
+		if i == 50 {
+			t.Fatal("expected the dead-letter log")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	common.ExpectString(t, letter.Hook, "momentums")
+	common.Expect(t, letter.Attempts, 2)
+	common.ExpectString(t, string(letter.Batch), string(first.body))
+
+	common.FailIfErr(t, sink.Stop())
+
+	// the sink is stopped while waiting to retry the next momentum
+	config.Hooks[0].MaxAttempts = 100
+	config.Hooks[0].InitialBackoff = time.Minute
+	config.Hooks[0].MaxBackoff = time.Minute
+	sink, err = webhook.NewSink(config, z.Chain())
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, sink.Start())
+	z.InsertNewMomentum()
+	common.ExpectTrue(t, receiveWebhookRequest(t, requests).delivery != first.delivery)
+	common.FailIfErr(t, sink.Stop())
+	atomic.StoreInt32(&failing, 0)
+
+	sink, err = webhook.NewSink(config, z.Chain())
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, sink.Start())
+	defer sink.Stop()
+	request := receiveWebhookRequest(t, requests)
+	items := decodeWebhookItems(t, request.batch)
+	common.Expect(t, len(items), 1)
+	common.Expect(t, items[0].Momentum.Height, uint64(3))
+}
+
+// - test that a batch stays queued until its dead-letter is written, without being sent again
+func TestWebhook_DeadLetterRetry(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	httpServer, requests := newWebhookServer(t, func() int {
+		return http.StatusInternalServerError
+	})
+	defer httpServer.Close()
+
+	// the dead-letter log can't be opened until its directory exists
+	dir := filepath.Join(t.TempDir(), "dead-letters")
+	config := &webhook.Config{
+		QueuePath:      filepath.Join(t.TempDir(), "queue"),
+		DeadLetterPath: filepath.Join(dir, "dead-letter.log"),
+		Hooks: []*webhook.HookConfig{{
+			Name:           "momentums",
+			URL:            httpServer.URL,
+			Momentums:      true,
+			BatchSize:      1,
+			MaxAttempts:    1,
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+		}},
+	}
+	sink, err := webhook.NewSink(config, z.Chain())
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, sink.Start())
+	defer sink.Stop()
+
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	first := receiveWebhookRequest(t, requests)
+	select {
+	case request := <-requests:
+		t.Fatalf("unexpected request %v before the dead-letter is written", request.delivery)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	common.FailIfErr(t, os.MkdirAll(dir, 0700))
+	second := receiveWebhookRequest(t, requests)
+	common.ExpectTrue(t, second.delivery != first.delivery)
+	common.Expect(t, decodeWebhookItems(t, first.batch)[0].Momentum.Height, uint64(2))
+	common.Expect(t, decodeWebhookItems(t, second.batch)[0].Momentum.Height, uint64(3))
+
+	var letter struct {
+		Delivery string `json:"delivery"`
+	}
+	file, err := os.Open(config.DeadLetterPath)
+	common.FailIfErr(t, err)
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	common.ExpectTrue(t, scanner.Scan())
+	common.FailIfErr(t, json.Unmarshal(scanner.Bytes(), &letter))
+	common.ExpectString(t, letter.Delivery, first.delivery)
+}
@@ -0,0 +1,131 @@
+package vm
+
+import (
+	"runtime/debug"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/vm_context"
+)
+
+// Simulation holds the outcome of running a send-block, and the embedded receive it triggers, on top of the frontier.
+// Nothing is inserted in the chain.
+type Simulation struct {
+	SendTransaction    *nom.AccountBlockTransaction
+	ReceiveTransaction *nom.AccountBlockTransaction // nil if the send-block is not addressed to an embedded contract
+	ReturnedError      error                        // error returned by the embedded method, if any
+}
+
+// SimulateBlock applies a user-send template the same way GenerateFromTemplate does, but without requiring a signature.
+// If the block is sent to an embedded contract, the contract-receive is generated on a snapshot of the
+// frontier momentum-store in which the send-block is already confirmed.
+func (s *Supervisor) SimulateBlock(template *nom.AccountBlock) (result *Simulation, internalErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			l := s.log.New("block", template.Header())
+			l.Error("vm panic when simulating block", "reason", err, "stack", string(debug.Stack()))
+
+			result = nil
+			internalErr = constants.ErrVmRunPanic
+		}
+	}()
+
+	if template.BlockType != nom.BlockTypeUserSend {
+		return nil, errors.Errorf("can only simulate BlockTypeUserSend")
+	}
+	if err := s.setAll(template); err != nil {
+		return nil, err
+	}
+	if err := s.verifier.AccountBlock(template); err != nil {
+		return nil, err
+	}
+	context := s.newBlockContext(template)
+	if err := s.setBlockPlasma(context, template); err != nil {
+		return nil, err
+	}
+	if err := NewVM(context).applyBlock(template); err != nil {
+		return nil, err
+	}
+	changes, err := context.Changes()
+	if err != nil {
+		return nil, err
+	}
+	template.ChangesHash = db.PatchHash(changes)
+	template.Hash = template.ComputeHash()
+
+	result = &Simulation{
+		SendTransaction: &nom.AccountBlockTransaction{
+			Block:   template,
+			Changes: changes,
+		},
+	}
+	if !types.IsEmbeddedAddress(template.ToAddress) {
+		return result, nil
+	}
+
+	momentumStore, err := s.confirmedSnapshot(template, changes)
+	if err != nil {
+		return nil, err
+	}
+	receiveContext := vm_context.NewAccountContext(
+		momentumStore,
+		s.chain.GetFrontierAccountStore(template.ToAddress).Snapshot(),
+		s.consensus.FixedPillarReader(momentumStore.Identifier()),
+	)
+	block, methodErr, err := NewVM(receiveContext).generateEmbeddedReceive(template.Hash)
+	if err != nil {
+		return nil, err
+	}
+	receiveChanges, err := receiveContext.Changes()
+	if err != nil {
+		return nil, err
+	}
+
+	result.ReceiveTransaction = &nom.AccountBlockTransaction{
+		Block:   block,
+		Changes: receiveChanges,
+	}
+	result.ReturnedError = methodErr
+	return result, nil
+}
+
+// confirmedSnapshot returns a snapshot of the frontier momentum-store in which all uncommitted blocks of the sender,
+// including the simulated one, are confirmed.
+func (s *Supervisor) confirmedSnapshot(block *nom.AccountBlock, changes db.Patch) (store.Momentum, error) {
+	momentumStore := s.chain.GetFrontierMomentumStore().Snapshot()
+	for _, uncommitted := range s.chain.GetUncommittedAccountBlocksByAddress(block.Address) {
+		if err := momentumStore.AddAccountBlockTransaction(uncommitted.Header(), s.chain.GetPatch(block.Address, uncommitted.Identifier())); err != nil {
+			return nil, err
+		}
+	}
+
+	// include the block itself, the same way db.Manager does when adding a transaction
+	data, err := block.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	temp := db.NewMemDB()
+	if err := db.SetFrontier(temp, block.Identifier(), data); err != nil {
+		return nil, err
+	}
+	frontierPatch, err := temp.Changes()
+	if err != nil {
+		return nil, err
+	}
+	patch := db.NewPatch()
+	if err := changes.Replay(patch); err != nil {
+		return nil, err
+	}
+	if err := frontierPatch.Replay(patch); err != nil {
+		return nil, err
+	}
+	if err := momentumStore.AddAccountBlockTransaction(block.Header(), patch); err != nil {
+		return nil, err
+	}
+	return momentumStore, nil
+}
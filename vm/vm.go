@@ -34,6 +34,12 @@ func errToStatus(err error) uint64 {
 	}
 }
 
+// EmbeddedReceiveSucceeded returns true if the contract-receive block reports that the embedded method executed without errors.
+// Only the status is persisted in the block, not the actual error.
+func EmbeddedReceiveSucceeded(block *nom.AccountBlock) bool {
+	return block.BlockType == nom.BlockTypeContractReceive && len(block.Data) == 8 && common.BytesToUint64(block.Data) == resultSuccess
+}
+
 type VM struct {
 	context vm_context.AccountVmContext
 }
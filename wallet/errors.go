@@ -9,16 +9,23 @@ var (
 	ErrKeyFileInvalidCipher  = errors.New("unable to read KeyFile. Invalid cipherName")
 	ErrKeyFileInvalidKDF     = errors.New("unable to read KeyFile. Invalid key derivation function (KDF)")
 
+	ErrKeyFileInvalidBaseAddress = errors.New("unable to read KeyFile. BaseAddress doesn't match the decrypted key store")
+
 	// === keyStore errors ===
 
 	ErrAddressNotFound = errors.New("the provided address could not be derived from the key store")
 	ErrWrongPassword   = errors.New("the key store could not be decrypted with the provided password")
+	ErrInvalidMnemonic = errors.New("the provided mnemonic is not a valid BIP39 mnemonic")
+	ErrKeyStoreZeroed  = errors.New("the key store has been zeroed")
 
 	// === manager errors ===
 
 	ErrKeyStoreLocked   = errors.New("the key store is locked")
 	ErrKeyStoreNotFound = errors.New("the provided key store could not be found in the data directory")
 
+	ErrKeyFileInvalidName   = errors.New("the KeyFile name must be a plain file name")
+	ErrKeyFileAlreadyExists = errors.New("a KeyFile with the same name already exists in the data directory")
+
 	// === derivation errors ===
 
 	ErrInvalidPath        = errors.New("invalid derivation path")
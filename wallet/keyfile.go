@@ -11,9 +11,10 @@ import (
 )
 
 const (
-	cryptoStoreVersion = 1
-	aesMode            = "aes-256-gcm"
-	argonName          = "argon2.IDKey"
+	cryptoStoreVersion           = 1
+	cryptoStorePassphraseVersion = 2 // also stores an encrypted BIP39 passphrase
+	aesMode                      = "aes-256-gcm"
+	argonName                    = "argon2.IDKey"
 )
 
 type KeyFile struct {
@@ -33,6 +34,9 @@ type cryptoParams struct {
 	CipherData   hexutil.Bytes `json:"cipherData"`
 	AesNonce     hexutil.Bytes `json:"nonce"`
 	Argon2Params argon2Params  `json:"argon2Params"`
+	// Only present in cryptoStorePassphraseVersion
+	PassphraseData  hexutil.Bytes `json:"passphraseData,omitempty"`
+	PassphraseNonce hexutil.Bytes `json:"passphraseNonce,omitempty"`
 }
 
 type argon2Params struct {
@@ -44,7 +48,11 @@ func ReadKeyFile(path string) (*KeyFile, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseKeyFile(path, keyFileJson)
+}
 
+// parseKeyFile checks the content of a KeyFile which is or will be stored at path
+func parseKeyFile(path string, keyFileJson []byte) (*KeyFile, error) {
 	k := &KeyFile{
 		Path: path,
 	}
@@ -52,7 +60,10 @@ func ReadKeyFile(path string) (*KeyFile, error) {
 	if err := json.Unmarshal(keyFileJson, k); err != nil {
 		return nil, err
 	}
-	if k.Version != cryptoStoreVersion {
+	if k.Version != cryptoStoreVersion && k.Version != cryptoStorePassphraseVersion {
+		return nil, ErrKeyFileInvalidVersion
+	}
+	if (k.Version == cryptoStorePassphraseVersion) != (len(k.Crypto.PassphraseData) != 0) {
 		return nil, ErrKeyFileInvalidVersion
 	}
 
@@ -70,7 +81,7 @@ func (kf *KeyFile) Write() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(kf.Path, keyFileJson, 700)
+	return os.WriteFile(kf.Path, keyFileJson, 0600)
 }
 
 func (kf *KeyFile) Decrypt(password string) (*KeyStore, error) {
@@ -84,6 +95,13 @@ func (kf *KeyFile) Decrypt(password string) (*KeyStore, error) {
 	if err != nil {
 		return nil, ErrWrongPassword
 	}
+	if kf.Version != cryptoStorePassphraseVersion {
+		return keyStoreFromEntropy(entropy)
+	}
 
-	return keyStoreFromEntropy(entropy)
+	passphrase, err := aesGCMDecrypt(derivedKey.password[:32], kf.Crypto.PassphraseData, kf.Crypto.PassphraseNonce)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+	return keyStoreFromEntropyAndPassphrase(entropy, string(passphrase))
 }
@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/tyler-smith/go-bip39"
@@ -14,23 +15,28 @@ const (
 )
 
 type KeyStore struct {
-	Entropy  []byte
-	Seed     []byte
-	Mnemonic string
+	Entropy    []byte
+	Seed       []byte
+	Mnemonic   string
+	Passphrase string // optional BIP39 passphrase, used together with Mnemonic to generate Seed
 
 	BaseAddress types.Address
 }
 
 func keyStoreFromEntropy(entropy []byte) (*KeyStore, error) {
+	return keyStoreFromEntropyAndPassphrase(entropy, "")
+}
+func keyStoreFromEntropyAndPassphrase(entropy []byte, passphrase string) (*KeyStore, error) {
 	mnemonic, err := bip39.NewMnemonic(entropy)
 	if err != nil {
 		return nil, err
 	}
 
 	ks := &KeyStore{
-		Entropy:  entropy,
-		Seed:     bip39.NewSeed(mnemonic, ""),
-		Mnemonic: mnemonic,
+		Entropy:    entropy,
+		Seed:       bip39.NewSeed(mnemonic, passphrase),
+		Mnemonic:   mnemonic,
+		Passphrase: passphrase,
 	}
 
 	// setup base address
@@ -43,13 +49,37 @@ func keyStoreFromEntropy(entropy []byte) (*KeyStore, error) {
 	return ks, nil
 }
 
+// NewKeyStore creates a KeyStore from random entropy
+func NewKeyStore() (*KeyStore, error) {
+	return keyStoreFromEntropy(getEntropyCSPRNG(entropySize))
+}
+
+// NewKeyStoreFromMnemonic creates a KeyStore from a BIP39 mnemonic and an optional passphrase.
+// The addresses are derived the same way as for a KeyStore created from the entropy of the mnemonic.
+func NewKeyStoreFromMnemonic(mnemonic, passphrase string) (*KeyStore, error) {
+	entropy, err := bip39.EntropyFromMnemonic(strings.Join(strings.Fields(mnemonic), " "))
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	return keyStoreFromEntropyAndPassphrase(entropy, passphrase)
+}
+
 func (ks *KeyStore) Zero() {
 	ks.Entropy = nil
 	ks.Seed = nil
 	ks.Mnemonic = ""
+	ks.Passphrase = ""
 	ks.BaseAddress = types.ZeroAddress
 }
 
+// ExportMnemonic returns the BIP39 mnemonic of the KeyStore. The passphrase, if any, is not part of the mnemonic.
+func (ks *KeyStore) ExportMnemonic() (string, error) {
+	if ks.Mnemonic == "" {
+		return "", ErrKeyStoreZeroed
+	}
+	return ks.Mnemonic, nil
+}
+
 func (ks *KeyStore) DeriveForFullPath(ipath string) (path string, key *KeyPair, err error) {
 	key, err = DeriveForPath(ipath, ks.Seed)
 	if err != nil {
@@ -86,7 +116,7 @@ func (ks *KeyStore) Encrypt(password string) (*KeyFile, error) {
 		return nil, err
 	}
 
-	return &KeyFile{
+	kf := &KeyFile{
 		BaseAddress: ks.BaseAddress,
 		Crypto: cryptoParams{
 			CipherName: aesMode,
@@ -99,5 +129,17 @@ func (ks *KeyStore) Encrypt(password string) (*KeyFile, error) {
 		},
 		Version:   cryptoStoreVersion,
 		Timestamp: time.Now().UTC().Unix(),
-	}, nil
+	}
+
+	// the passphrase is stored in a newer version, so older software refuses the KeyFile instead of deriving other addresses
+	if ks.Passphrase != "" {
+		passphraseData, passphraseNonce, err := aesGCMEncrypt(derivedKey.password[:], []byte(ks.Passphrase))
+		if err != nil {
+			return nil, err
+		}
+		kf.Crypto.PassphraseData = passphraseData
+		kf.Crypto.PassphraseNonce = passphraseNonce
+		kf.Version = cryptoStorePassphraseVersion
+	}
+	return kf, nil
 }
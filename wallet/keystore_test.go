@@ -0,0 +1,61 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/zenon-network/go-zenon/common"
+)
+
+const testMnemonic = "route become dream access impulse price inform obtain engage ski believe awful absent pig thing vibrant possible exotic flee pepper marble rural fire fancy"
+
+func TestKeyStore_Mnemonic(t *testing.T) {
+	ks, err := NewKeyStoreFromMnemonic(testMnemonic, "")
+	common.FailIfErr(t, err)
+	fromEntropy, err := keyStoreFromEntropy(ks.Entropy)
+	common.FailIfErr(t, err)
+	for i := uint32(0); i < 3; i += 1 {
+		_, expected, err := fromEntropy.DeriveForIndexPath(i)
+		common.FailIfErr(t, err)
+		_, current, err := ks.DeriveForIndexPath(i)
+		common.FailIfErr(t, err)
+		common.Expect(t, current.Address, expected.Address)
+	}
+	common.ExpectString(t, ks.BaseAddress.String(), "z1qqjnwjjpnue8xmmpanz6csze6tcmtzzdtfsww7")
+
+	// whitespace is normalized
+	mnemonic, err := ks.ExportMnemonic()
+	common.FailIfErr(t, err)
+	common.ExpectString(t, mnemonic, testMnemonic)
+	again, err := NewKeyStoreFromMnemonic(" "+testMnemonic+"\n", "")
+	common.FailIfErr(t, err)
+	common.Expect(t, again.BaseAddress, ks.BaseAddress)
+
+	_, err = NewKeyStoreFromMnemonic("route become dream", "")
+	common.ExpectError(t, err, ErrInvalidMnemonic)
+
+	ks.Zero()
+	_, err = ks.ExportMnemonic()
+	common.ExpectError(t, err, ErrKeyStoreZeroed)
+}
+
+func TestKeyStore_MnemonicPassphrase(t *testing.T) {
+	ks, err := NewKeyStoreFromMnemonic(testMnemonic, "passphrase")
+	common.FailIfErr(t, err)
+	common.ExpectString(t, ks.BaseAddress.String(), "z1qpcree5t2mtwepmh9nlhmj79v407ga25g738sq")
+
+	// the passphrase survives encryption & the KeyFile uses the newer version
+	kf, err := ks.Encrypt("password")
+	common.FailIfErr(t, err)
+	common.Expect(t, kf.Version, cryptoStorePassphraseVersion)
+	decrypted, err := kf.Decrypt("password")
+	common.FailIfErr(t, err)
+	common.Expect(t, decrypted.BaseAddress, ks.BaseAddress)
+	common.ExpectString(t, decrypted.Passphrase, "passphrase")
+
+	// KeyFiles without passphrase keep the old version
+	plain, err := NewKeyStoreFromMnemonic(testMnemonic, "")
+	common.FailIfErr(t, err)
+	kf, err = plain.Encrypt("password")
+	common.FailIfErr(t, err)
+	common.Expect(t, kf.Version, cryptoStoreVersion)
+}
@@ -4,13 +4,19 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
 )
 
 const (
 	DefaultMaxIndex = 128
+
+	entropySize = 32
 )
 
 type Config struct {
@@ -22,8 +28,11 @@ type Manager struct {
 	config *Config
 	log    common.Logger
 
-	encrypted map[string]*KeyFile  // map from path to
-	decrypted map[string]*KeyStore // map from path to
+	// changes protects all maps since the manager is used concurrently by the rpc
+	changes   sync.Mutex
+	encrypted map[string]*KeyFile    // map from path to
+	decrypted map[string]*KeyStore   // map from path to
+	timers    map[string]*time.Timer // map from path to pending auto-lock
 }
 
 func New(config *Config) *Manager {
@@ -39,6 +48,7 @@ func New(config *Config) *Manager {
 		config:    config,
 		encrypted: make(map[string]*KeyFile),
 		decrypted: make(map[string]*KeyStore),
+		timers:    make(map[string]*time.Timer),
 		log:       common.WalletLogger,
 	}
 }
@@ -50,21 +60,30 @@ func (m *Manager) Start() error {
 	}
 	m.log.Info("successfully ensured WalletDir exists", "wallet-dir-path", m.config.WalletDir)
 
-	m.encrypted = make(map[string]*KeyFile)
 	keyFiles, err := m.ListEntropyFilesInStandardDir()
 	if err != nil {
 		m.log.Error("wallet start err", "err", err)
 		return err
 	}
+
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	m.encrypted = make(map[string]*KeyFile)
 	for _, keyFile := range keyFiles {
 		m.encrypted[keyFile.Path] = keyFile
 	}
 	return nil
 }
 func (m *Manager) Stop() {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	for _, timer := range m.timers {
+		timer.Stop()
+	}
 	for _, ks := range m.decrypted {
 		ks.Zero()
 	}
+	m.timers = nil
 	m.decrypted = nil
 	m.encrypted = nil
 }
@@ -78,6 +97,11 @@ func (m *Manager) MakePathAbsolut(path string) string {
 }
 
 func (m *Manager) GetKeyFile(path string) (*KeyFile, error) {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	return m.getKeyFile(path)
+}
+func (m *Manager) getKeyFile(path string) (*KeyFile, error) {
 	path = m.MakePathAbsolut(path)
 	if kf, ok := m.encrypted[path]; ok == false {
 		return nil, ErrKeyStoreNotFound
@@ -86,6 +110,11 @@ func (m *Manager) GetKeyFile(path string) (*KeyFile, error) {
 	}
 }
 func (m *Manager) GetKeyStore(path string) (*KeyStore, error) {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	return m.getKeyStore(path)
+}
+func (m *Manager) getKeyStore(path string) (*KeyStore, error) {
 	path = m.MakePathAbsolut(path)
 	if _, ok := m.encrypted[path]; ok == false {
 		return nil, ErrKeyStoreNotFound
@@ -129,8 +158,96 @@ func (m *Manager) ListEntropyFilesInStandardDir() ([]*KeyFile, error) {
 	return files, nil
 }
 
+// ListKeyFiles returns the known KeyFiles, sorted by path
+func (m *Manager) ListKeyFiles() []*KeyFile {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	files := make([]*KeyFile, 0, len(m.encrypted))
+	for _, kf := range m.encrypted {
+		files = append(files, kf)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// CreateKeyFile generates a new KeyStore from random entropy and stores it encrypted with password
+// in the wallet directory, under name.
+func (m *Manager) CreateKeyFile(name, password string) (*KeyFile, error) {
+	ks, err := NewKeyStore()
+	if err != nil {
+		return nil, err
+	}
+	defer ks.Zero()
+	kf, err := ks.Encrypt(password)
+	if err != nil {
+		return nil, err
+	}
+	return kf, m.addKeyFile(name, kf)
+}
+
+// ImportKeyFile stores the content of an existing KeyFile in the wallet directory, under name.
+// The password is only used to make sure that the KeyFile can be decrypted.
+func (m *Manager) ImportKeyFile(name string, keyFileJson []byte, password string) (*KeyFile, error) {
+	kf, err := parseKeyFile("", keyFileJson)
+	if err != nil {
+		return nil, err
+	}
+	ks, err := kf.Decrypt(password)
+	if err != nil {
+		return nil, err
+	}
+	defer ks.Zero()
+	if ks.BaseAddress != kf.BaseAddress {
+		return nil, ErrKeyFileInvalidBaseAddress
+	}
+	return kf, m.addKeyFile(name, kf)
+}
+
+// ImportMnemonic stores the KeyStore of a BIP39 mnemonic & optional passphrase in the wallet directory, under name.
+func (m *Manager) ImportMnemonic(name, mnemonic, passphrase, password string) (*KeyFile, error) {
+	ks, err := NewKeyStoreFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer ks.Zero()
+	kf, err := ks.Encrypt(password)
+	if err != nil {
+		return nil, err
+	}
+	return kf, m.addKeyFile(name, kf)
+}
+func (m *Manager) addKeyFile(name string, kf *KeyFile) error {
+	if name == "" || filepath.Base(name) != name || strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+		return ErrKeyFileInvalidName
+	}
+
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	kf.Path = m.MakePathAbsolut(name)
+	if _, ok := m.encrypted[kf.Path]; ok {
+		return ErrKeyFileAlreadyExists
+	}
+	if _, err := os.Stat(kf.Path); err == nil {
+		return ErrKeyFileAlreadyExists
+	}
+	if err := kf.Write(); err != nil {
+		return err
+	}
+	m.encrypted[kf.Path] = kf
+	m.log.Info("added keyFile", "path", kf.Path, "base-address", kf.BaseAddress)
+	return nil
+}
+
 // Unlock also adds keyFile to encrypted if not present
 func (m *Manager) Unlock(path, password string) error {
+	return m.UnlockWithTimeout(path, password, 0)
+}
+
+// UnlockWithTimeout locks the keyFile again after timeout. A timeout of 0 keeps it unlocked until Lock is called.
+// Unlocking an already unlocked keyFile replaces the previous timeout.
+func (m *Manager) UnlockWithTimeout(path, password string, timeout time.Duration) error {
 	path = m.MakePathAbsolut(path)
 	kf, err := m.GetKeyFile(path)
 	if err != nil {
@@ -141,18 +258,44 @@ func (m *Manager) Unlock(path, password string) error {
 		return err
 	}
 
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	m.lock(path)
 	m.encrypted[path] = kf
 	m.decrypted[path] = ks
+	if timeout != 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(timeout, func() {
+			m.changes.Lock()
+			defer m.changes.Unlock()
+			// the keyFile might have been unlocked again in the meantime
+			if m.timers[path] == timer {
+				m.log.Info("locking keyFile after timeout", "path", path)
+				m.lock(path)
+			}
+		})
+		m.timers[path] = timer
+	}
 	return nil
 }
 func (m *Manager) Lock(path string) {
-	path = m.MakePathAbsolut(path)
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	m.lock(m.MakePathAbsolut(path))
+}
+func (m *Manager) lock(path string) {
+	if timer, ok := m.timers[path]; ok == true {
+		timer.Stop()
+		delete(m.timers, path)
+	}
 	if ks, ok := m.decrypted[path]; ok == true {
 		ks.Zero()
-		m.decrypted[path] = nil
+		delete(m.decrypted, path)
 	}
 }
 func (m *Manager) IsUnlocked(path string) (bool, error) {
+	m.changes.Lock()
+	defer m.changes.Unlock()
 	path = m.MakePathAbsolut(path)
 	if _, ok := m.encrypted[path]; ok == false {
 		return false, ErrKeyStoreNotFound
@@ -160,3 +303,48 @@ func (m *Manager) IsUnlocked(path string) (bool, error) {
 	_, ok := m.decrypted[path]
 	return ok, nil
 }
+
+// DeriveKeyPairs derives count consecutive key-pairs, starting with index, from an unlocked keyFile
+func (m *Manager) DeriveKeyPairs(path string, index, count uint32) ([]*KeyPair, error) {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	ks, err := m.getKeyStore(path)
+	if err != nil {
+		return nil, err
+	}
+	keyPairs := make([]*KeyPair, 0, count)
+	for i := uint32(0); i < count; i += 1 {
+		_, kp, err := ks.DeriveForIndexPath(index + i)
+		if err != nil {
+			return nil, err
+		}
+		keyPairs = append(keyPairs, kp)
+	}
+	return keyPairs, nil
+}
+
+// FindKeyPair searches the key-pair of address in an unlocked keyFile
+func (m *Manager) FindKeyPair(path string, address types.Address) (*KeyPair, error) {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	ks, err := m.getKeyStore(path)
+	if err != nil {
+		return nil, err
+	}
+	kp, _, err := ks.FindAddress(address)
+	return kp, err
+}
+
+// FindUnlockedKeyPair searches the key-pair of address in all unlocked keyFiles
+func (m *Manager) FindUnlockedKeyPair(address types.Address) (*KeyPair, error) {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	for _, ks := range m.decrypted {
+		if kp, _, err := ks.FindAddress(address); err == nil {
+			return kp, nil
+		} else if err != ErrAddressNotFound {
+			return nil, err
+		}
+	}
+	return nil, ErrAddressNotFound
+}
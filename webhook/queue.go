@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/zenon-network/go-zenon/common"
+)
+
+// queue persists the pending deliveries of every hook, in insertion order
+type queue struct {
+	db      *leveldb.DB
+	changes sync.Mutex
+	next    map[string]uint64
+}
+
+type entry struct {
+	key  []byte
+	data []byte
+}
+
+func openQueue(path string) (*queue, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &queue{
+		db:   db,
+		next: make(map[string]uint64),
+	}, nil
+}
+
+var (
+	entryPrefix    = []byte{0}
+	sequencePrefix = []byte{1}
+)
+
+func hookKey(prefix []byte, hook string) []byte {
+	key := make([]byte, 0, len(prefix)+len(hook)+2)
+	key = append(key, prefix...)
+	key = append(key, byte(len(hook)))
+	key = append(key, hook...)
+	return append(key, 0)
+}
+
+// nextSequence returns the sequence of the next entry of hook, the queue must be locked.
+// The sequence is persisted, so it isn't reused after the entries are removed.
+func (q *queue) nextSequence(hook string) (uint64, error) {
+	if next, ok := q.next[hook]; ok {
+		return next, nil
+	}
+	next := uint64(0)
+	data, err := q.db.Get(hookKey(sequencePrefix, hook), nil)
+	if err == nil {
+		next = common.BytesToUint64(data)
+	} else if err != leveldb.ErrNotFound {
+		return 0, err
+	}
+	q.next[hook] = next
+	return next, nil
+}
+
+func (q *queue) push(hook string, data []byte) error {
+	q.changes.Lock()
+	defer q.changes.Unlock()
+	sequence, err := q.nextSequence(hook)
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	batch.Put(append(hookKey(entryPrefix, hook), common.Uint64ToBytes(sequence)...), data)
+	batch.Put(hookKey(sequencePrefix, hook), common.Uint64ToBytes(sequence+1))
+	if err := q.db.Write(batch, nil); err != nil {
+		return err
+	}
+	q.next[hook] = sequence + 1
+	return nil
+}
+
+// peek returns at most limit of the oldest entries of hook
+func (q *queue) peek(hook string, limit int) ([]*entry, error) {
+	iterator := q.db.NewIterator(util.BytesPrefix(hookKey(entryPrefix, hook)), nil)
+	defer iterator.Release()
+	entries := make([]*entry, 0, limit)
+	for len(entries) < limit && iterator.Next() {
+		entries = append(entries, &entry{
+			key:  append([]byte{}, iterator.Key()...),
+			data: append([]byte{}, iterator.Value()...),
+		})
+	}
+	return entries, iterator.Error()
+}
+
+func (q *queue) remove(entries []*entry) error {
+	batch := new(leveldb.Batch)
+	for _, e := range entries {
+		batch.Delete(e.key)
+	}
+	return q.db.Write(batch, nil)
+}
+
+func (q *queue) close() error {
+	return q.db.Close()
+}
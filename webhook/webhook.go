@@ -0,0 +1,398 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
+)
+
+const (
+	DefaultBatchSize      = 100
+	DefaultMaxAttempts    = 10
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = 5 * time.Minute
+
+	maxNameLength  = 64
+	requestTimeout = 30 * time.Second
+
+	SignatureHeader = "X-Zenon-Signature"
+	DeliveryHeader  = "X-Zenon-Delivery"
+
+	InsertType   = "insert"
+	RollbackType = "rollback"
+)
+
+var (
+	ErrInvalidName   = errors.New("webhook name must have between 1 and 64 characters")
+	ErrDuplicateName = errors.New("webhook name is used by multiple webhooks")
+)
+
+type HookConfig struct {
+	Name   string // identifies the queue of the hook, must be unique
+	URL    string
+	Secret []byte // signs the body with HMAC-SHA256
+
+	Momentums bool                          // deliver every momentum, not only the ones with matching account-blocks
+	Filter    *subscribe.AccountBlockFilter // if nil, all account-blocks match
+
+	BatchSize      int           // max number of momentums in one request
+	MaxAttempts    int           // the batch is moved to the dead-letter log after this number of failed requests
+	InitialBackoff time.Duration // wait after the first failed request, doubled after each failure
+	MaxBackoff     time.Duration
+}
+
+type Config struct {
+	QueuePath      string // directory of the queue database
+	DeadLetterPath string // batches which couldn't be delivered are appended to this file, one JSON per line
+	Hooks          []*HookConfig
+}
+
+// Item is a momentum inserted in, or deleted from the chain, with the account-blocks which match the filter of the hook
+type Item struct {
+	Type          string                    `json:"type"`
+	Momentum      *subscribe.Momentum       `json:"momentum"`
+	AccountBlocks []*subscribe.AccountBlock `json:"accountBlocks"`
+}
+
+// Batch is the body of a request. The SignatureHeader of the request is "sha256=" followed by the
+// hex encoded HMAC-SHA256 of the body & the DeliveryHeader is the same for all the attempts of a batch.
+type Batch struct {
+	Hook  string            `json:"hook"`
+	Items []json.RawMessage `json:"items"`
+}
+
+type deadLetter struct {
+	Hook     string          `json:"hook"`
+	URL      string          `json:"url"`
+	Delivery string          `json:"delivery"`
+	Attempts int             `json:"attempts"`
+	Reason   string          `json:"reason"`
+	Time     int64           `json:"time"`
+	Batch    json.RawMessage `json:"batch,omitempty"`
+	Entries  [][]byte        `json:"entries,omitempty"` // the raw queue entries of a batch which can't be encoded
+}
+
+// Sink queues the momentums & account-blocks matching each hook and POSTs them in batches to the URL of the hook.
+// Pending deliveries are persisted, so they are retried after a restart.
+type Sink interface {
+	chain.MomentumEventListener
+
+	Start() error
+	Stop() error
+}
+
+type hook struct {
+	*HookConfig
+	matcher *subscribe.AccountBlockMatcher
+	wake    chan struct{}
+}
+
+type sink struct {
+	log    common.Logger
+	config *Config
+	chain  chain.Chain
+	hooks  []*hook
+	queue  *queue
+	client *http.Client
+
+	deadLetters sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewSink(config *Config, chain chain.Chain) (Sink, error) {
+	hooks := make([]*hook, 0, len(config.Hooks))
+	names := make(map[string]bool)
+	for _, original := range config.Hooks {
+		hookConfig := *original
+		if hookConfig.BatchSize <= 0 {
+			hookConfig.BatchSize = DefaultBatchSize
+		}
+		if hookConfig.MaxAttempts <= 0 {
+			hookConfig.MaxAttempts = DefaultMaxAttempts
+		}
+		if hookConfig.InitialBackoff <= 0 {
+			hookConfig.InitialBackoff = DefaultInitialBackoff
+		}
+		if hookConfig.MaxBackoff <= 0 {
+			hookConfig.MaxBackoff = DefaultMaxBackoff
+		}
+		if len(hookConfig.Name) == 0 || len(hookConfig.Name) > maxNameLength {
+			return nil, ErrInvalidName
+		}
+		if names[hookConfig.Name] {
+			return nil, ErrDuplicateName
+		}
+		names[hookConfig.Name] = true
+		matcher, err := subscribe.NewAccountBlockMatcher(hookConfig.Filter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid filter of webhook %v", hookConfig.Name)
+		}
+		hooks = append(hooks, &hook{
+			HookConfig: &hookConfig,
+			matcher:    matcher,
+			wake:       make(chan struct{}, 1),
+		})
+	}
+
+	queue, err := openQueue(config.QueuePath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &sink{
+		log:    common.WebhookLogger,
+		config: config,
+		chain:  chain,
+		hooks:  hooks,
+		queue:  queue,
+		client: &http.Client{Timeout: requestTimeout},
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+func (s *sink) Start() error {
+	s.log.Info("starting ...", "hooks", len(s.hooks))
+	s.chain.Register(s)
+	for _, h := range s.hooks {
+		s.wg.Add(1)
+		go func(h *hook) {
+			defer s.wg.Done()
+			defer common.RecoverStack()
+			s.deliverAll(h)
+		}(h)
+	}
+	return nil
+}
+func (s *sink) Stop() error {
+	s.log.Info("stopping ...")
+	s.chain.UnRegister(s)
+	s.cancel()
+	s.wg.Wait()
+	return s.queue.close()
+}
+
+func (s *sink) InsertMomentum(detailed *nom.DetailedMomentum) {
+	s.push(InsertType, detailed)
+}
+func (s *sink) DeleteMomentum(detailed *nom.DetailedMomentum) {
+	s.push(RollbackType, detailed)
+}
+
+// push is called while the momentum is inserted, so items are queued in the same order as the momentums
+func (s *sink) push(itemType string, detailed *nom.DetailedMomentum) {
+	for _, h := range s.hooks {
+		blocks := h.matcher.Match(detailed)
+		if len(blocks) == 0 && !h.Momentums {
+			continue
+		}
+		data, err := json.Marshal(&Item{
+			Type: itemType,
+			Momentum: &subscribe.Momentum{
+				Hash:   detailed.Momentum.Hash,
+				Height: detailed.Momentum.Height,
+			},
+			AccountBlocks: blocks,
+		})
+		if err != nil {
+			s.log.Error("failed to encode item", "hook", h.Name, "reason", err)
+			continue
+		}
+		if err := s.queue.push(h.Name, data); err != nil {
+			s.log.Error("failed to queue item", "hook", h.Name, "momentum-identifier", detailed.Momentum.Identifier(), "reason", err)
+			continue
+		}
+		select {
+		case h.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// deliverAll delivers the queued batches of the hook until the sink is stopped
+func (s *sink) deliverAll(h *hook) {
+	backoff := h.InitialBackoff
+	for {
+		entries, err := s.queue.peek(h.Name, h.BatchSize)
+		if err != nil {
+			s.log.Error("failed to read queue", "hook", h.Name, "reason", err)
+			if !s.wait(h, &backoff) {
+				return
+			}
+			continue
+		}
+		backoff = h.InitialBackoff
+		if len(entries) == 0 {
+			select {
+			case <-h.wake:
+				continue
+			case <-s.ctx.Done():
+				return
+			}
+		}
+		if !s.deliver(h, entries) {
+			return
+		}
+	}
+}
+
+// deliver sends the batch until it succeeds or MaxAttempts is reached, then removes it from the queue.
+// Batches which can't be encoded are moved to the dead-letter log without being sent. Writing the dead-letter
+// & removing the batch are retried with backoff. Returns false if the sink was stopped before.
+func (s *sink) deliver(h *hook, entries []*entry) bool {
+	batch := &Batch{
+		Hook:  h.Name,
+		Items: make([]json.RawMessage, 0, len(entries)),
+	}
+	for _, e := range entries {
+		batch.Items = append(batch.Items, e.data)
+	}
+	delivery := fmt.Sprintf("%v-%x", h.Name, entries[0].key[len(entries[0].key)-8:])
+
+	var letter *deadLetter
+	body, err := json.Marshal(batch)
+	if err != nil {
+		// only corrupted queue entries can't be encoded, they would never be delivered
+		s.log.Error("failed to encode batch", "hook", h.Name, "delivery", delivery, "reason", err)
+		letter = &deadLetter{
+			Hook:     h.Name,
+			URL:      h.URL,
+			Delivery: delivery,
+			Reason:   err.Error(),
+			Time:     time.Now().Unix(),
+			Entries:  make([][]byte, 0, len(entries)),
+		}
+		for _, e := range entries {
+			letter.Entries = append(letter.Entries, e.data)
+		}
+	} else {
+		backoff := h.InitialBackoff
+		for attempt := 1; ; attempt += 1 {
+			err = s.post(h, delivery, body)
+			if err == nil {
+				break
+			}
+			s.log.Info("failed to deliver batch", "hook", h.Name, "delivery", delivery, "attempt", attempt, "reason", err)
+			if attempt >= h.MaxAttempts {
+				letter = &deadLetter{
+					Hook:     h.Name,
+					URL:      h.URL,
+					Delivery: delivery,
+					Attempts: attempt,
+					Reason:   err.Error(),
+					Time:     time.Now().Unix(),
+					Batch:    body,
+				}
+				break
+			}
+			if !s.wait(h, &backoff) {
+				return false
+			}
+		}
+	}
+
+	// the batch stays queued until the dead-letter is written, so it isn't lost
+	backoff := h.InitialBackoff
+	for letter != nil {
+		err := s.writeDeadLetter(letter)
+		if err == nil {
+			break
+		}
+		s.log.Error("failed to write dead-letter log", "hook", h.Name, "delivery", delivery, "path", s.config.DeadLetterPath, "reason", err)
+		if !s.wait(h, &backoff) {
+			return false
+		}
+	}
+
+	// the batch isn't delivered again, only the removal is retried
+	backoff = h.InitialBackoff
+	for {
+		err := s.queue.remove(entries)
+		if err == nil {
+			return true
+		}
+		s.log.Error("failed to remove delivered batch", "hook", h.Name, "delivery", delivery, "reason", err)
+		if !s.wait(h, &backoff) {
+			return false
+		}
+	}
+}
+
+// wait sleeps for backoff & doubles it, up to the MaxBackoff of the hook. Returns false if the sink was stopped before.
+func (s *sink) wait(h *hook, backoff *time.Duration) bool {
+	select {
+	case <-time.After(*backoff):
+	case <-s.ctx.Done():
+		return false
+	}
+	*backoff *= 2
+	if *backoff > h.MaxBackoff {
+		*backoff = h.MaxBackoff
+	}
+	return true
+}
+
+func (s *sink) post(h *hook, delivery string, body []byte) error {
+	mac := hmac.New(sha256.New, h.Secret)
+	mac.Write(body)
+
+	request, err := http.NewRequestWithContext(s.ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	request.Header.Set(DeliveryHeader, delivery)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %v", response.Status)
+	}
+	return nil
+}
+
+// writeDeadLetter appends letter to the dead-letter log
+func (s *sink) writeDeadLetter(letter *deadLetter) error {
+	s.log.Error("moving batch to the dead-letter log", "hook", letter.Hook, "delivery", letter.Delivery, "reason", letter.Reason)
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode dead-letter")
+	}
+
+	s.deadLetters.Lock()
+	defer s.deadLetters.Unlock()
+	file, err := os.OpenFile(s.config.DeadLetterPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -5,16 +5,36 @@ import (
 
 	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/vm/constants"
 	"github.com/zenon-network/go-zenon/wallet"
 )
 
+// DefaultMaxHistoryDepth is one day of momentums
+const DefaultMaxHistoryDepth = 8640
+
 type Config struct {
 	MinPeers         int
 	DataDir          string
 	ProducingKeyPair *wallet.KeyPair
 	GenesisConfig    store.Genesis
+
+	// Consensus overrides constants.ConsensusConfig if set, it's used by the dev chain
+	Consensus *constants.Consensus
+
+	// MaxHistoryDepth is the number of momentums behind the frontier of which the state is served by the RPC apis.
+	// If 0, DefaultMaxHistoryDepth is used.
+	MaxHistoryDepth uint64
+
+	// EnableIndexer indexes the account-blocks by address. The first start indexes the whole chain.
+	EnableIndexer bool
 }
 
+func (c *Config) HistoryDepth() uint64 {
+	if c.MaxHistoryDepth == 0 {
+		return DefaultMaxHistoryDepth
+	}
+	return c.MaxHistoryDepth
+}
 func (c *Config) NewDBManager(inside string) db.Manager {
 	return db.NewLevelDBManager(path.Join(c.DataDir, inside))
 }
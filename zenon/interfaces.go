@@ -3,6 +3,7 @@ package zenon
 import (
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/indexer"
 	"github.com/zenon-network/go-zenon/pillar"
 	"github.com/zenon-network/go-zenon/protocol"
 	"github.com/zenon-network/go-zenon/verifier"
@@ -20,4 +21,5 @@ type Zenon interface {
 	Producer() pillar.Manager
 	Config() *Config
 	Broadcaster() protocol.Broadcaster
+	Indexer() indexer.Indexer // nil if the indexer is disabled
 }
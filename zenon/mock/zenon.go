@@ -9,6 +9,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/genesis"
@@ -18,6 +19,7 @@ import (
 	"github.com/zenon-network/go-zenon/common/db"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/indexer"
 	"github.com/zenon-network/go-zenon/pillar"
 	"github.com/zenon-network/go-zenon/protocol"
 	"github.com/zenon-network/go-zenon/verifier"
@@ -122,9 +124,11 @@ type mockZenon struct {
 	log              log15.Logger
 	producerLogSaver *ProducerLogSaver
 
+	config     *zenon.Config
 	pillars    []pillar.Manager
 	chain      chain.Chain
 	consensus  consensus.Consensus
+	indexer    indexer.Indexer
 	supervisor *vm.Supervisor
 
 	loggers              []log15.Logger
@@ -294,6 +298,7 @@ func (zenon *mockZenon) ExpectBalance(address types.Address, standard types.Zeno
 func (zenon *mockZenon) Init() error {
 	common.DealWithErr(zenon.chain.Init())
 	common.DealWithErr(zenon.consensus.Init())
+	common.DealWithErr(zenon.indexer.Init())
 	for _, pillarE := range zenon.pillars {
 		common.DealWithErr(pillarE.Init())
 	}
@@ -302,6 +307,7 @@ func (zenon *mockZenon) Init() error {
 func (zenon *mockZenon) Start() error {
 	common.DealWithErr(zenon.chain.Start())
 	common.DealWithErr(zenon.consensus.Start())
+	common.DealWithErr(zenon.indexer.Start())
 	for _, pillarE := range zenon.pillars {
 		common.DealWithErr(pillarE.Start())
 	}
@@ -311,11 +317,13 @@ func (zenon *mockZenon) Stop() error {
 	for _, pillarE := range zenon.pillars {
 		common.DealWithErr(pillarE.Stop())
 	}
+	common.DealWithErr(zenon.indexer.Stop())
 	common.DealWithErr(zenon.consensus.Stop())
 	common.DealWithErr(zenon.chain.Stop())
 
 	zenon.chain = nil
 	zenon.consensus = nil
+	zenon.indexer = nil
 	zenon.pillars = nil
 
 	for i := range zenon.loggers {
@@ -346,11 +354,14 @@ func (zenon *mockZenon) Producer() pillar.Manager {
 	return nil
 }
 func (zenon *mockZenon) Config() *zenon.Config {
-	return nil
+	return zenon.config
 }
 func (zenon *mockZenon) Broadcaster() protocol.Broadcaster {
 	return zenon
 }
+func (zenon *mockZenon) Indexer() indexer.Indexer {
+	return zenon.indexer
+}
 
 func NewMockZenon(t common.T) MockZenon {
 	return newMockZenon(t, consensus.EpochDuration)
@@ -367,13 +378,17 @@ func newMockZenon(t common.T, customEpochDuration time.Duration) MockZenon {
 	consensus.EpochDuration = customEpochDuration
 
 	ch := chain.NewChain(db.NewLevelDBManager(t.TempDir()), genesis.NewGenesis(g.EmbeddedGenesis))
-	cs := consensus.NewConsensus(db.NewMemDB(), ch, true)
+	cs := consensus.NewConsensus(db.NewMemDB(), ch, nil, true)
+	indexerDB, err := leveldb.OpenFile(t.TempDir(), nil)
+	common.DealWithErr(err)
 	supervisor := vm.NewSupervisor(ch, cs)
 	zenon := &mockZenon{
 		t:                    t,
 		log:                  common.ZenonLogger,
+		config:               &zenon.Config{},
 		chain:                ch,
 		consensus:            cs,
+		indexer:              indexer.NewIndexer(indexerDB, ch),
 		supervisor:           supervisor,
 		loggers:              make([]log15.Logger, len(AllLoggers)),
 		handlers:             make([]log15.Handler, len(AllLoggers)),
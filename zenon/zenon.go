@@ -1,8 +1,13 @@
 package zenon
 
 import (
+	"path"
+
+	"github.com/syndtr/goleveldb/leveldb"
+
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/indexer"
 	"github.com/zenon-network/go-zenon/pillar"
 	"github.com/zenon-network/go-zenon/protocol"
 	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
@@ -19,6 +24,7 @@ type zenon struct {
 	chain       chain.Chain
 	pillar      pillar.Manager
 	consensus   consensus.Consensus
+	indexer     indexer.Indexer
 	evPrinter   EventPrinter
 	broadcaster protocol.Broadcaster
 }
@@ -29,8 +35,15 @@ func NewZenon(cfg *Config) (Zenon, error) {
 	}
 
 	z.chain = chain.NewChain(cfg.NewDBManager("nom"), cfg.GenesisConfig)
-	z.consensus = consensus.NewConsensus(cfg.NewLevelDB("consensus"), z.chain, false)
+	z.consensus = consensus.NewConsensus(cfg.NewLevelDB("consensus"), z.chain, cfg.Consensus, false)
 	z.verifier = verifier.NewVerifier(z.chain, z.consensus)
+	if cfg.EnableIndexer {
+		indexerDB, err := leveldb.OpenFile(path.Join(cfg.DataDir, "indexer"), nil)
+		if err != nil {
+			return nil, err
+		}
+		z.indexer = indexer.NewIndexer(indexerDB, z.chain)
+	}
 
 	chainBridge := protocol.NewChainBridge(z.chain, z.consensus, z.verifier, vm.NewSupervisor(z.chain, z.consensus))
 	z.protocol = protocol.NewProtocolManager(cfg.MinPeers, z.chain.ChainIdentifier(), chainBridge)
@@ -54,6 +67,11 @@ func (z *zenon) Init() error {
 	if err := z.consensus.Init(); err != nil {
 		return err
 	}
+	if z.indexer != nil {
+		if err := z.indexer.Init(); err != nil {
+			return err
+		}
+	}
 	if err := z.evPrinter.Init(); err != nil {
 		return err
 	}
@@ -74,6 +92,11 @@ func (z *zenon) Start() error {
 	if err := z.consensus.Start(); err != nil {
 		return err
 	}
+	if z.indexer != nil {
+		if err := z.indexer.Start(); err != nil {
+			return err
+		}
+	}
 	if err := z.evPrinter.Start(); err != nil {
 		return err
 	}
@@ -98,6 +121,11 @@ func (z *zenon) Stop() error {
 	if err := z.evPrinter.Stop(); err != nil {
 		return err
 	}
+	if z.indexer != nil {
+		if err := z.indexer.Stop(); err != nil {
+			return err
+		}
+	}
 	if err := z.consensus.Stop(); err != nil {
 		return err
 	}
@@ -129,3 +157,6 @@ func (z *zenon) Config() *Config {
 func (z *zenon) Broadcaster() protocol.Broadcaster {
 	return z.broadcaster
 }
+func (z *zenon) Indexer() indexer.Indexer {
+	return z.indexer
+}